@@ -0,0 +1,172 @@
+package goglmath
+
+import (
+	"math"
+)
+
+// ArcballMode selects how cursor positions are mapped onto the virtual sphere.
+type ArcballMode int
+
+// Arcball modes.
+const (
+	// ArcballShoemake maps the cursor onto a unit sphere, points outside the sphere are mapped onto its rim.
+	// Rotation angle is twice the angle between the sphere points, hence the rotation is independent of the drag path.
+	ArcballShoemake ArcballMode = iota
+
+	// ArcballBell maps the cursor onto a sphere blended into a hyperbolic sheet, avoiding the discontinuity at the rim.
+	// Rotation angle is the angle between the sphere points.
+	ArcballBell
+)
+
+// ArcballPoint maps the cursor position to a unit vector on the virtual sphere.
+//
+// Cursor coordinates are relative to the viewport top-left corner, with Y growing downwards, as usually reported by windowing systems.
+// The sphere is centered on the viewport and its radius is half of the smaller viewport dimension.
+// The returned point is in view space: +X right, +Y up, +Z towards the viewer.
+func ArcballPoint(mode ArcballMode, viewportWidth, viewportHeight int, cursorX, cursorY float64) Vector3 {
+	size := float64(viewportWidth)
+	if viewportHeight < viewportWidth {
+		size = float64(viewportHeight)
+	}
+	x := (2*cursorX - float64(viewportWidth)) / size
+	y := (float64(viewportHeight) - 2*cursorY) / size
+	r2 := x*x + y*y
+
+	switch mode {
+	case ArcballBell:
+		if r2 <= 0.5 {
+			return Vector3{x, y, math.Sqrt(1 - r2)}
+		}
+		return Vector3{x, y, 0.5 / math.Sqrt(r2)}.Normalize()
+	default:
+		if r2 <= 1 {
+			return Vector3{x, y, math.Sqrt(1 - r2)}
+		}
+		return Vector3{x, y, 0}.Normalize()
+	}
+}
+
+// ArcballConstrain projects the sphere point onto the great circle perpendicular to axis.
+// Dragging constrained points rotates only around axis.
+func ArcballConstrain(p, axis Vector3) Vector3 {
+	a := axis.Normalize()
+	proj := p.Sub(a.Scale(p.Dot(a)))
+	if proj.LengthSquared() < 0.000001 {
+		// p is parallel to axis: any point on the circle will do
+		proj = a.Cross(Vector3{1, 0, 0})
+		if proj.LengthSquared() < 0.000001 {
+			proj = a.Cross(Vector3{0, 1, 0})
+		}
+	}
+	proj = proj.Normalize()
+	if proj.Z < 0 {
+		// keep the point on the hemisphere facing the viewer
+		proj = proj.Negate()
+	}
+	return proj
+}
+
+// arcballRotation builds the rotation taking sphere point from to sphere point to.
+func arcballRotation(mode ArcballMode, from, to Vector3) Quaternion {
+	if mode == ArcballBell {
+		return NewQuaternionFromTo(from, to)
+	}
+	c := from.Cross(to)
+	return Quaternion{c.X, c.Y, c.Z, from.Dot(to)}
+}
+
+// ArcballRotation returns the view space rotation for dragging the cursor from start to current position.
+// See ArcballPoint for the cursor coordinates.
+//
+// The view space rotation R is applied to a model as:
+// M = V^-1 * R * V * M // V = view matrix
+// For a camera looking down -Z with no rotation (V is a translation), R can be applied directly: M = R * M
+func ArcballRotation(mode ArcballMode, viewportWidth, viewportHeight int, startX, startY, currentX, currentY float64) Quaternion {
+	from := ArcballPoint(mode, viewportWidth, viewportHeight, startX, startY)
+	to := ArcballPoint(mode, viewportWidth, viewportHeight, currentX, currentY)
+	return arcballRotation(mode, from, to)
+}
+
+// ArcballRotationAxis is similar to ArcballRotation, but rotates only around the view space axis.
+func ArcballRotationAxis(mode ArcballMode, viewportWidth, viewportHeight int, startX, startY, currentX, currentY float64, axis Vector3) Quaternion {
+	from := ArcballConstrain(ArcballPoint(mode, viewportWidth, viewportHeight, startX, startY), axis)
+	to := ArcballConstrain(ArcballPoint(mode, viewportWidth, viewportHeight, currentX, currentY), axis)
+	return arcballRotation(mode, from, to)
+}
+
+// SetArcballMatrix builds the rotation matrix for dragging the cursor from start to current position.
+// See ArcballRotation.
+func SetArcballMatrix(rotationMatrix *Matrix4, mode ArcballMode, viewportWidth, viewportHeight int, startX, startY, currentX, currentY float64) {
+	SetQuaternionMatrix(rotationMatrix, ArcballRotation(mode, viewportWidth, viewportHeight, startX, startY, currentX, currentY))
+}
+
+// Arcball accumulates rotations from successive drags.
+type Arcball struct {
+	Mode ArcballMode
+	Axis Vector3 // constraint axis in view space, zero vector means unconstrained
+
+	base     Quaternion // rotation accumulated from finished drags
+	drag     Quaternion // rotation of current drag
+	start    Vector3
+	dragging bool
+}
+
+// NewArcball creates an arcball with identity rotation.
+func NewArcball(mode ArcballMode) *Arcball {
+	return &Arcball{
+		Mode: mode,
+		base: NewQuaternionIdentity(),
+		drag: NewQuaternionIdentity(),
+	}
+}
+
+func (a *Arcball) point(viewportWidth, viewportHeight int, cursorX, cursorY float64) Vector3 {
+	p := ArcballPoint(a.Mode, viewportWidth, viewportHeight, cursorX, cursorY)
+	if a.Axis != (Vector3{}) {
+		p = ArcballConstrain(p, a.Axis)
+	}
+	return p
+}
+
+// Begin starts a drag at the cursor position.
+func (a *Arcball) Begin(viewportWidth, viewportHeight int, cursorX, cursorY float64) {
+	a.start = a.point(viewportWidth, viewportHeight, cursorX, cursorY)
+	a.drag = NewQuaternionIdentity()
+	a.dragging = true
+}
+
+// Drag updates the current drag with the cursor position.
+// Drag is ignored outside Begin/End.
+func (a *Arcball) Drag(viewportWidth, viewportHeight int, cursorX, cursorY float64) {
+	if !a.dragging {
+		return
+	}
+	a.drag = arcballRotation(a.Mode, a.start, a.point(viewportWidth, viewportHeight, cursorX, cursorY))
+}
+
+// End finishes the current drag, accumulating its rotation.
+func (a *Arcball) End() {
+	if !a.dragging {
+		return
+	}
+	a.base = a.drag.Multiply(a.base).Normalize()
+	a.drag = NewQuaternionIdentity()
+	a.dragging = false
+}
+
+// Reset discards all rotation.
+func (a *Arcball) Reset() {
+	a.base = NewQuaternionIdentity()
+	a.drag = NewQuaternionIdentity()
+	a.dragging = false
+}
+
+// Rotation returns the accumulated rotation, including the current drag.
+func (a *Arcball) Rotation() Quaternion {
+	return a.drag.Multiply(a.base).Normalize()
+}
+
+// RotationMatrix builds the rotation matrix for the accumulated rotation.
+func (a *Arcball) RotationMatrix(rotationMatrix *Matrix4) {
+	SetQuaternionMatrix(rotationMatrix, a.Rotation())
+}
@@ -0,0 +1,68 @@
+package goglmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestArcballPoint(t *testing.T) {
+	if got, want := ArcballPoint(ArcballShoemake, 200, 100, 100, 50), (Vector3{0, 0, 1}); !vector3Close(got, want) {
+		t.Errorf("center: expected=%v got=%v", want, got)
+	}
+	if got, want := ArcballPoint(ArcballShoemake, 200, 100, 200, 50), (Vector3{1, 0, 0}); !vector3Close(got, want) {
+		t.Errorf("outside: expected=%v got=%v", want, got)
+	}
+	if got, want := ArcballPoint(ArcballBell, 100, 100, 50, 0), (Vector3{0, 1, 0.5}.Normalize()); !vector3Close(got, want) {
+		t.Errorf("bell top: expected=%v got=%v", want, got)
+	}
+}
+
+func TestArcballRotation(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  ArcballMode
+		angle float64
+	}{
+		{"shoemake", ArcballShoemake, math.Pi / 3},
+		{"bell", ArcballBell, math.Pi / 6},
+	}
+	for _, test := range tests {
+		// drag from center halfway to the right edge
+		q := ArcballRotation(test.mode, 100, 100, 50, 50, 75, 50)
+		want := NewQuaternionAxisAngle(Vector3{0, 1, 0}, test.angle)
+		if !quaternionClose(q, want) {
+			t.Errorf("%s: expected=%v got=%v", test.name, want, q)
+		}
+	}
+}
+
+func TestArcballRotationAxis(t *testing.T) {
+	q := ArcballRotationAxis(ArcballShoemake, 100, 100, 50, 50, 75, 50, Vector3{1, 0, 0})
+	if !quaternionClose(q, NewQuaternionIdentity()) {
+		t.Errorf("drag perpendicular to constraint: expected identity got=%v", q)
+	}
+	q = ArcballRotationAxis(ArcballShoemake, 100, 100, 50, 50, 50, 25, Vector3{1, 0, 0})
+	if axis, _ := q.AxisAngle(); !vector3Close(axis, Vector3{-1, 0, 0}) {
+		t.Errorf("drag up: expected axis=%v got=%v", Vector3{-1, 0, 0}, axis)
+	}
+}
+
+func TestArcballAccumulate(t *testing.T) {
+	a := NewArcball(ArcballShoemake)
+	for i := 0; i < 2; i++ {
+		a.Begin(100, 100, 50, 50)
+		a.Drag(100, 100, 75, 50)
+		a.End()
+	}
+	want := NewQuaternionAxisAngle(Vector3{0, 1, 0}, 2*math.Pi/3)
+	if got := a.Rotation(); !quaternionClose(got, want) {
+		t.Errorf("expected=%v got=%v", want, got)
+	}
+
+	var m, w Matrix4
+	a.RotationMatrix(&m)
+	SetQuaternionMatrix(&w, want)
+	if !matrix4Close(&m, &w, 0.00001) {
+		t.Errorf("matrix: expected=%v got=%v", w, m)
+	}
+}
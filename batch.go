@@ -0,0 +1,109 @@
+package goglmath
+
+import (
+	"errors"
+	"math"
+)
+
+// VertexMode selects how TransformVertices treats the vertex components.
+type VertexMode int
+
+// Vertex modes.
+const (
+	VertexPoint        VertexMode = iota // position: x y z 1, result w is discarded
+	VertexPointProject                   // position: x y z 1, result divided by w
+	VertexDirection                      // direction: x y z 0, translation is ignored
+	VertexNormal                         // surface normal: transformed by the normal matrix and normalized
+)
+
+// VertexLayout describes where the x y z components are found in an interleaved float32 buffer.
+//
+// Vertex i components are at: Offset+i*Stride, Offset+i*Stride+1, Offset+i*Stride+2
+type VertexLayout struct {
+	Offset int // index of the x component of the first vertex
+	Stride int // distance in floats between consecutive vertices, 0 means tightly packed (3)
+}
+
+// VertexCount returns how many vertices the buffer holds for the layout.
+func (l VertexLayout) VertexCount(buf []float32) int {
+	stride := l.stride()
+	if l.Offset < 0 || stride < 3 || len(buf) < l.Offset+3 {
+		return 0
+	}
+	return (len(buf)-l.Offset-3)/stride + 1
+}
+
+func (l VertexLayout) stride() int {
+	if l.Stride == 0 {
+		return 3
+	}
+	return l.Stride
+}
+
+// TransformVertices transforms the x y z components of every vertex in src, writing results into dst.
+//
+// dst and src use the same layout; components outside x y z are left untouched in dst.
+// dst may be src for in-place transform.
+// dst must be at least as long as src.
+// TransformVertices does not allocate.
+func (m *Matrix4) TransformVertices(dst, src []float32, layout VertexLayout, mode VertexMode) error {
+	stride := layout.stride()
+	if layout.Offset < 0 || stride < 3 {
+		return errors.New("transformVertices: bad layout")
+	}
+	if len(dst) < len(src) {
+		return errors.New("transformVertices: short destination buffer")
+	}
+	count := layout.VertexCount(src)
+
+	d := &m.data
+	switch mode {
+	case VertexPoint:
+		for i, j := 0, layout.Offset; i < count; i, j = i+1, j+stride {
+			x, y, z := src[j], src[j+1], src[j+2]
+			dst[j] = d[0]*x + d[4]*y + d[8]*z + d[12]
+			dst[j+1] = d[1]*x + d[5]*y + d[9]*z + d[13]
+			dst[j+2] = d[2]*x + d[6]*y + d[10]*z + d[14]
+		}
+	case VertexPointProject:
+		for i, j := 0, layout.Offset; i < count; i, j = i+1, j+stride {
+			x, y, z := src[j], src[j+1], src[j+2]
+			w := d[3]*x + d[7]*y + d[11]*z + d[15]
+			dst[j] = (d[0]*x + d[4]*y + d[8]*z + d[12]) / w
+			dst[j+1] = (d[1]*x + d[5]*y + d[9]*z + d[13]) / w
+			dst[j+2] = (d[2]*x + d[6]*y + d[10]*z + d[14]) / w
+		}
+	case VertexDirection:
+		for i, j := 0, layout.Offset; i < count; i, j = i+1, j+stride {
+			x, y, z := src[j], src[j+1], src[j+2]
+			dst[j] = d[0]*x + d[4]*y + d[8]*z
+			dst[j+1] = d[1]*x + d[5]*y + d[9]*z
+			dst[j+2] = d[2]*x + d[6]*y + d[10]*z
+		}
+	case VertexNormal:
+		var normal Matrix3
+		if err := m.NormalMatrix(&normal); err != nil {
+			return err
+		}
+		n := &normal.data
+		for i, j := 0, layout.Offset; i < count; i, j = i+1, j+stride {
+			x, y, z := src[j], src[j+1], src[j+2]
+			tx := n[0]*x + n[3]*y + n[6]*z
+			ty := n[1]*x + n[4]*y + n[7]*z
+			tz := n[2]*x + n[5]*y + n[8]*z
+			length := float32(math.Sqrt(float64(tx*tx + ty*ty + tz*tz)))
+			if length > 0 {
+				tx /= length
+				ty /= length
+				tz /= length
+			}
+			dst[j] = tx
+			dst[j+1] = ty
+			dst[j+2] = tz
+		}
+	default:
+		return errors.New("transformVertices: bad vertex mode")
+	}
+
+	return nil
+}
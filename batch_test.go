@@ -0,0 +1,101 @@
+package goglmath
+
+import (
+	"math"
+	"testing"
+)
+
+func float32Close(a, b float32) bool {
+	return math.Abs(float64(a-b)) < 0.0001
+}
+
+func TestTransformVerticesInterleaved(t *testing.T) {
+	m := NewMatrix4Identity()
+	m.Translate(1, 2, 3, 1)
+	m.Scale(2, 2, 2, 1)
+
+	// layout: u v x y z
+	buf := []float32{
+		9, 9, 1, 0, 0,
+		8, 8, 0, 1, 0,
+	}
+	layout := VertexLayout{Offset: 2, Stride: 5}
+	if n := layout.VertexCount(buf); n != 2 {
+		t.Fatalf("vertex count: expected=2 got=%d", n)
+	}
+	if err := m.TransformVertices(buf, buf, layout, VertexPoint); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []float32{
+		9, 9, 3, 2, 3,
+		8, 8, 1, 4, 3,
+	}
+	for i := range want {
+		if !float32Close(buf[i], want[i]) {
+			t.Errorf("index %d: expected=%v got=%v", i, want[i], buf[i])
+		}
+	}
+}
+
+func TestTransformVerticesModes(t *testing.T) {
+	src := []float32{1, 1, -2}
+	dst := make([]float32, 3)
+
+	var P Matrix4
+	SetPerspectiveMatrix(&P, math.Pi/2, 1, 1, 10)
+	P.TransformVertices(dst, src, VertexLayout{}, VertexPointProject)
+	got := Vector3{float64(dst[0]), float64(dst[1]), float64(dst[2])}
+	if want := P.TransformPoint(Vector3{1, 1, -2}); !vector3Close(got, want) {
+		t.Errorf("project: expected=%v got=%v", want, got)
+	}
+
+	m := NewMatrix4Identity()
+	m.Translate(5, 5, 5, 1)
+	m.Scale(1, 4, 1, 1)
+	m.TransformVertices(dst, src, VertexLayout{}, VertexDirection)
+	if dst[0] != 1 || dst[1] != 4 || dst[2] != -2 {
+		t.Errorf("direction: expected=[1 4 -2] got=%v", dst)
+	}
+
+	normal := []float32{0, 1, 1}
+	m.TransformVertices(dst, normal, VertexLayout{}, VertexNormal)
+	got = Vector3{float64(dst[0]), float64(dst[1]), float64(dst[2])}
+	if want := (Vector3{0, 0.25, 1}).Normalize(); !vector3Close(got, want) {
+		t.Errorf("normal: expected=%v got=%v", want, got)
+	}
+}
+
+func TestTransformVerticesErrors(t *testing.T) {
+	m := NewMatrix4Identity()
+	buf := make([]float32, 6)
+	if err := m.TransformVertices(buf, buf, VertexLayout{Stride: 2}, VertexPoint); err == nil {
+		t.Errorf("expected error for short stride")
+	}
+	if err := m.TransformVertices(buf[:3], buf, VertexLayout{}, VertexPoint); err == nil {
+		t.Errorf("expected error for short destination")
+	}
+}
+
+func TestTransformVerticesAllocs(t *testing.T) {
+	m := NewMatrix4Identity()
+	m.Rotate(1, 0, 0, 0, 1, 0)
+	buf := make([]float32, 3*100)
+	for _, mode := range []VertexMode{VertexPoint, VertexPointProject, VertexDirection, VertexNormal} {
+		allocs := testing.AllocsPerRun(10, func() {
+			m.TransformVertices(buf, buf, VertexLayout{}, mode)
+		})
+		if allocs != 0 {
+			t.Errorf("mode %d: expected=0 allocations got=%v", mode, allocs)
+		}
+	}
+}
+
+func BenchmarkTransformVertices(b *testing.B) {
+	m := NewMatrix4Identity()
+	m.Translate(1, 2, 3, 1)
+	buf := make([]float32, 8*1000)
+	layout := VertexLayout{Stride: 8}
+	for n := 0; n < b.N; n++ {
+		m.TransformVertices(buf, buf, layout, VertexPoint)
+	}
+}
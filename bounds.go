@@ -0,0 +1,102 @@
+package goglmath
+
+import (
+	"math"
+)
+
+// Plane is the set of points p satisfying Normal.Dot(p) + D = 0.
+// The positive half-space is on the side Normal points to.
+type Plane struct {
+	Normal Vector3
+	D      float64
+}
+
+// NewPlane creates the plane containing point with the given normal.
+func NewPlane(normal, point Vector3) Plane {
+	n := normal.Normalize()
+	return Plane{n, -n.Dot(point)}
+}
+
+// Normalize scales the plane so that its normal has unit length.
+// Planes with null normal are returned unchanged.
+func (p Plane) Normalize() Plane {
+	length := p.Normal.Length()
+	if length == 0 {
+		return p
+	}
+	inv := 1.0 / length
+	return Plane{p.Normal.Scale(inv), p.D * inv}
+}
+
+// Distance calculates the signed distance from point to the (normalized) plane.
+// The distance is positive when point is on the side the normal points to.
+func (p Plane) Distance(point Vector3) float64 {
+	return p.Normal.Dot(point) + p.D
+}
+
+// IntersectPlanes calculates the single point shared by three planes.
+// It reports false if two or more planes are parallel.
+func IntersectPlanes(p1, p2, p3 Plane) (Vector3, bool) {
+	n23 := p2.Normal.Cross(p3.Normal)
+	det := p1.Normal.Dot(n23)
+	if closeToZero(det) {
+		return Vector3{}, false
+	}
+	n31 := p3.Normal.Cross(p1.Normal)
+	n12 := p1.Normal.Cross(p2.Normal)
+	p := n23.Scale(-p1.D).Add(n31.Scale(-p2.D)).Add(n12.Scale(-p3.D))
+	return p.Scale(1 / det), true
+}
+
+// Sphere is a bounding sphere.
+type Sphere struct {
+	Center Vector3
+	Radius float64
+}
+
+// AABB is an axis-aligned bounding box.
+type AABB struct {
+	Min, Max Vector3
+}
+
+// Center calculates the center of the box.
+func (b AABB) Center() Vector3 {
+	return b.Min.Lerp(b.Max, .5)
+}
+
+// HalfExtents calculates half the size of the box along each axis.
+func (b AABB) HalfExtents() Vector3 {
+	return b.Max.Sub(b.Min).Scale(.5)
+}
+
+// OBB is an oriented bounding box.
+type OBB struct {
+	Center      Vector3
+	Axes        [3]Vector3 // unit, mutually orthogonal local axes
+	HalfExtents Vector3    // half the size of the box along each local axis
+}
+
+// NewOBB creates an oriented box from the local axis-aligned box transformed by the model matrix.
+// The model matrix must not have shear.
+func NewOBB(model *Matrix4, box AABB) OBB {
+	var o OBB
+	o.Center = model.TransformPoint(box.Center())
+	h := box.HalfExtents()
+	ext := [3]float64{h.X, h.Y, h.Z}
+	unit := [3]Vector3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	for i, u := range unit {
+		axis := model.TransformDirection(u)
+		length := axis.Length()
+		o.Axes[i] = axis.Normalize()
+		ext[i] *= length
+	}
+	o.HalfExtents = Vector3{ext[0], ext[1], ext[2]}
+	return o
+}
+
+// projectedRadius calculates the radius of the box projected onto direction n.
+func (o OBB) projectedRadius(n Vector3) float64 {
+	return math.Abs(n.Dot(o.Axes[0]))*o.HalfExtents.X +
+		math.Abs(n.Dot(o.Axes[1]))*o.HalfExtents.Y +
+		math.Abs(n.Dot(o.Axes[2]))*o.HalfExtents.Z
+}
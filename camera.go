@@ -0,0 +1,379 @@
+package goglmath
+
+import (
+	"math"
+)
+
+// Camera controllers turn input deltas into view matrices.
+//
+// Controllers are input-agnostic: the application converts mouse, keyboard
+// or gamepad input into deltas, calls Update once per frame and then
+// ViewMatrix to obtain the matrix.
+//
+// Input methods change the goal state. Update moves the current state
+// towards the goal, exponentially, with time constant Smoothing (seconds).
+// Smoothing 0 disables smoothing: Update snaps to the goal.
+//
+// Angles are radians.
+// yaw = rotation around +Y, yaw 0 looks towards -Z
+// pitch = rotation around the camera right axis, positive pitch looks up
+
+// DefaultPitchLimit keeps pitch slightly away from the poles, where the up vector is parallel to the view direction.
+const DefaultPitchLimit = math.Pi/2 - 0.001
+
+// smoothFactor returns the fraction of the remaining distance to the goal covered in dt seconds.
+func smoothFactor(smoothing, dt float64) float64 {
+	if smoothing <= 0 {
+		return 1
+	}
+	return 1 - math.Exp(-dt/smoothing)
+}
+
+func clamp(v, min, max float64) float64 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// yawPitchForward returns the view direction for yaw and pitch.
+func yawPitchForward(yaw, pitch float64) Vector3 {
+	sy, cy := math.Sincos(yaw)
+	sp, cp := math.Sincos(pitch)
+	return Vector3{-sy * cp, sp, -cy * cp}
+}
+
+func setViewMatrixForward(m *Matrix4, pos, forward, up Vector3) {
+	focus := pos.Add(forward)
+	SetViewMatrix(m, focus.X, focus.Y, focus.Z, up.X, up.Y, up.Z, pos.X, pos.Y, pos.Z)
+}
+
+type orbitState struct {
+	target   Vector3
+	distance float64
+	yaw      float64
+	pitch    float64
+}
+
+// OrbitCamera orbits around a target point.
+//
+// yaw = 0, pitch = 0 places the camera at +Z from the target, looking towards -Z.
+// Positive pitch raises the camera above the target.
+type OrbitCamera struct {
+	MinDistance float64 // zoom limit
+	MaxDistance float64 // zoom limit, 0 means unlimited
+	MinPitch    float64
+	MaxPitch    float64
+	PanCenter   Vector3 // target is kept within PanRadius from PanCenter
+	PanRadius   float64 // 0 means unlimited
+	Smoothing   float64 // time constant in seconds, 0 disables smoothing
+
+	goal    orbitState
+	current orbitState
+}
+
+// NewOrbitCamera creates an orbit camera with pitch limited to DefaultPitchLimit and unlimited zoom and pan.
+func NewOrbitCamera(target Vector3, distance, yaw, pitch float64) *OrbitCamera {
+	c := &OrbitCamera{
+		MinPitch: -DefaultPitchLimit,
+		MaxPitch: DefaultPitchLimit,
+	}
+	c.goal = orbitState{target: target, distance: distance, yaw: yaw, pitch: pitch}
+	c.constrain()
+	c.Snap()
+	return c
+}
+
+func (c *OrbitCamera) constrain() {
+	g := &c.goal
+	g.pitch = clamp(g.pitch, c.MinPitch, c.MaxPitch)
+	if g.distance < c.MinDistance {
+		g.distance = c.MinDistance
+	}
+	if c.MaxDistance > 0 && g.distance > c.MaxDistance {
+		g.distance = c.MaxDistance
+	}
+	if c.PanRadius > 0 {
+		offset := g.target.Sub(c.PanCenter)
+		if offset.Length() > c.PanRadius {
+			g.target = c.PanCenter.Add(offset.Normalize().Scale(c.PanRadius))
+		}
+	}
+}
+
+// Rotate orbits the camera around the target.
+func (c *OrbitCamera) Rotate(deltaYaw, deltaPitch float64) {
+	c.goal.yaw += deltaYaw
+	c.goal.pitch += deltaPitch
+	c.constrain()
+}
+
+// Zoom multiplies the distance to the target by factor.
+// factor < 1 moves closer, factor > 1 moves away.
+func (c *OrbitCamera) Zoom(factor float64) {
+	c.goal.distance *= factor
+	c.constrain()
+}
+
+// Pan moves the target along the camera right and up axes.
+// Deltas are scaled by the distance to the target, hence a delta of 1 at distance 10 moves the target 10 units.
+func (c *OrbitCamera) Pan(deltaX, deltaY float64) {
+	g := &c.goal
+	right, up := c.axes(g)
+	g.target = g.target.Add(right.Scale(deltaX * g.distance)).Add(up.Scale(deltaY * g.distance))
+	c.constrain()
+}
+
+// SetTarget sets the goal target.
+func (c *OrbitCamera) SetTarget(target Vector3) {
+	c.goal.target = target
+	c.constrain()
+}
+
+// SetDistance sets the goal distance.
+func (c *OrbitCamera) SetDistance(distance float64) {
+	c.goal.distance = distance
+	c.constrain()
+}
+
+// SetAngles sets the goal yaw and pitch.
+func (c *OrbitCamera) SetAngles(yaw, pitch float64) {
+	c.goal.yaw = yaw
+	c.goal.pitch = pitch
+	c.constrain()
+}
+
+// Target returns the current target.
+func (c *OrbitCamera) Target() Vector3 {
+	return c.current.target
+}
+
+// Distance returns the current distance to the target.
+func (c *OrbitCamera) Distance() float64 {
+	return c.current.distance
+}
+
+// Angles returns the current yaw and pitch.
+func (c *OrbitCamera) Angles() (yaw, pitch float64) {
+	return c.current.yaw, c.current.pitch
+}
+
+// Update moves the current state towards the goal.
+// dt = elapsed time in seconds
+func (c *OrbitCamera) Update(dt float64) {
+	f := smoothFactor(c.Smoothing, dt)
+	cur, g := &c.current, &c.goal
+	cur.target = cur.target.Lerp(g.target, f)
+	cur.distance += (g.distance - cur.distance) * f
+	cur.yaw += (g.yaw - cur.yaw) * f
+	cur.pitch += (g.pitch - cur.pitch) * f
+}
+
+// Snap sets the current state to the goal, skipping smoothing.
+func (c *OrbitCamera) Snap() {
+	c.current = c.goal
+}
+
+func (c *OrbitCamera) axes(s *orbitState) (right, up Vector3) {
+	sy, cy := math.Sincos(s.yaw)
+	sp, cp := math.Sincos(s.pitch)
+	right = Vector3{cy, 0, -sy}
+	up = Vector3{-sy * sp, cp, -cy * sp}
+	return
+}
+
+// Position returns the current camera position.
+func (c *OrbitCamera) Position() Vector3 {
+	s := &c.current
+	return s.target.Sub(yawPitchForward(s.yaw, -s.pitch).Scale(s.distance))
+}
+
+// ViewMatrix builds the view matrix for the current state.
+func (c *OrbitCamera) ViewMatrix(viewMatrix *Matrix4) {
+	s := &c.current
+	pos := c.Position()
+	_, up := c.axes(s)
+	SetViewMatrix(viewMatrix, s.target.X, s.target.Y, s.target.Z, up.X, up.Y, up.Z, pos.X, pos.Y, pos.Z)
+}
+
+type firstPersonState struct {
+	position Vector3
+	yaw      float64
+	pitch    float64
+}
+
+// FirstPersonCamera looks around with yaw and pitch and walks on the XZ plane.
+type FirstPersonCamera struct {
+	MinPitch  float64
+	MaxPitch  float64
+	Smoothing float64 // time constant in seconds, 0 disables smoothing
+
+	goal    firstPersonState
+	current firstPersonState
+}
+
+// NewFirstPersonCamera creates a first-person camera with pitch limited to DefaultPitchLimit.
+func NewFirstPersonCamera(position Vector3, yaw, pitch float64) *FirstPersonCamera {
+	c := &FirstPersonCamera{
+		MinPitch: -DefaultPitchLimit,
+		MaxPitch: DefaultPitchLimit,
+	}
+	c.goal = firstPersonState{position: position, yaw: yaw, pitch: clamp(pitch, c.MinPitch, c.MaxPitch)}
+	c.Snap()
+	return c
+}
+
+// Look turns the camera.
+func (c *FirstPersonCamera) Look(deltaYaw, deltaPitch float64) {
+	c.goal.yaw += deltaYaw
+	c.goal.pitch = clamp(c.goal.pitch+deltaPitch, c.MinPitch, c.MaxPitch)
+}
+
+// Move walks the camera.
+// forward and right move on the XZ plane, following yaw only; up moves along +Y.
+func (c *FirstPersonCamera) Move(forward, right, up float64) {
+	g := &c.goal
+	sy, cy := math.Sincos(g.yaw)
+	g.position.X += -sy*forward + cy*right
+	g.position.Y += up
+	g.position.Z += -cy*forward - sy*right
+}
+
+// SetPosition sets the goal position.
+func (c *FirstPersonCamera) SetPosition(position Vector3) {
+	c.goal.position = position
+}
+
+// SetAngles sets the goal yaw and pitch.
+func (c *FirstPersonCamera) SetAngles(yaw, pitch float64) {
+	c.goal.yaw = yaw
+	c.goal.pitch = clamp(pitch, c.MinPitch, c.MaxPitch)
+}
+
+// Position returns the current position.
+func (c *FirstPersonCamera) Position() Vector3 {
+	return c.current.position
+}
+
+// Angles returns the current yaw and pitch.
+func (c *FirstPersonCamera) Angles() (yaw, pitch float64) {
+	return c.current.yaw, c.current.pitch
+}
+
+// Forward returns the current view direction.
+func (c *FirstPersonCamera) Forward() Vector3 {
+	return yawPitchForward(c.current.yaw, c.current.pitch)
+}
+
+// Update moves the current state towards the goal.
+// dt = elapsed time in seconds
+func (c *FirstPersonCamera) Update(dt float64) {
+	f := smoothFactor(c.Smoothing, dt)
+	cur, g := &c.current, &c.goal
+	cur.position = cur.position.Lerp(g.position, f)
+	cur.yaw += (g.yaw - cur.yaw) * f
+	cur.pitch += (g.pitch - cur.pitch) * f
+}
+
+// Snap sets the current state to the goal, skipping smoothing.
+func (c *FirstPersonCamera) Snap() {
+	c.current = c.goal
+}
+
+// ViewMatrix builds the view matrix for the current state.
+func (c *FirstPersonCamera) ViewMatrix(viewMatrix *Matrix4) {
+	setViewMatrixForward(viewMatrix, c.current.position, c.Forward(), Vector3{0, 1, 0})
+}
+
+type flyState struct {
+	position    Vector3
+	orientation Quaternion
+}
+
+// FlyCamera moves and rotates freely, with six degrees of freedom.
+//
+// Identity orientation looks towards -Z with +Y up.
+type FlyCamera struct {
+	Smoothing float64 // time constant in seconds, 0 disables smoothing
+
+	goal    flyState
+	current flyState
+}
+
+// NewFlyCamera creates a fly camera.
+func NewFlyCamera(position Vector3, orientation Quaternion) *FlyCamera {
+	c := &FlyCamera{}
+	c.goal = flyState{position: position, orientation: orientation.Normalize()}
+	c.Snap()
+	return c
+}
+
+// Rotate turns the camera around its own axes.
+// yaw = around camera up, pitch = around camera right, roll = around view direction (positive rolls clockwise)
+func (c *FlyCamera) Rotate(deltaYaw, deltaPitch, deltaRoll float64) {
+	g := &c.goal
+	q := g.orientation
+	q = q.Multiply(NewQuaternionAxisAngle(Vector3{0, 1, 0}, deltaYaw))
+	q = q.Multiply(NewQuaternionAxisAngle(Vector3{1, 0, 0}, deltaPitch))
+	q = q.Multiply(NewQuaternionAxisAngle(Vector3{0, 0, -1}, deltaRoll))
+	g.orientation = q.Normalize()
+}
+
+// Move moves the camera along its own axes.
+func (c *FlyCamera) Move(forward, right, up float64) {
+	g := &c.goal
+	delta := Vector3{right, up, -forward}
+	g.position = g.position.Add(g.orientation.Rotate(delta))
+}
+
+// SetPosition sets the goal position.
+func (c *FlyCamera) SetPosition(position Vector3) {
+	c.goal.position = position
+}
+
+// SetOrientation sets the goal orientation.
+func (c *FlyCamera) SetOrientation(orientation Quaternion) {
+	c.goal.orientation = orientation.Normalize()
+}
+
+// Position returns the current position.
+func (c *FlyCamera) Position() Vector3 {
+	return c.current.position
+}
+
+// Orientation returns the current orientation.
+func (c *FlyCamera) Orientation() Quaternion {
+	return c.current.orientation
+}
+
+// Forward returns the current view direction.
+func (c *FlyCamera) Forward() Vector3 {
+	return c.current.orientation.Rotate(Vector3{0, 0, -1})
+}
+
+// Up returns the current up direction.
+func (c *FlyCamera) Up() Vector3 {
+	return c.current.orientation.Rotate(Vector3{0, 1, 0})
+}
+
+// Update moves the current state towards the goal.
+// dt = elapsed time in seconds
+func (c *FlyCamera) Update(dt float64) {
+	f := smoothFactor(c.Smoothing, dt)
+	cur, g := &c.current, &c.goal
+	cur.position = cur.position.Lerp(g.position, f)
+	cur.orientation = cur.orientation.Slerp(g.orientation, f)
+}
+
+// Snap sets the current state to the goal, skipping smoothing.
+func (c *FlyCamera) Snap() {
+	c.current = c.goal
+}
+
+// ViewMatrix builds the view matrix for the current state.
+func (c *FlyCamera) ViewMatrix(viewMatrix *Matrix4) {
+	setViewMatrixForward(viewMatrix, c.current.position, c.Forward(), c.Up())
+}
@@ -0,0 +1,97 @@
+package goglmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOrbitCamera(t *testing.T) {
+	c := NewOrbitCamera(Vector3{1, 0, 0}, 5, 0, 0)
+	if got, want := c.Position(), (Vector3{1, 0, 5}); !vector3Close(got, want) {
+		t.Errorf("position: expected=%v got=%v", want, got)
+	}
+
+	c.Rotate(math.Pi/2, 0)
+	c.Update(0)
+	if got, want := c.Position(), (Vector3{6, 0, 0}); !vector3Close(got, want) {
+		t.Errorf("position after yaw: expected=%v got=%v", want, got)
+	}
+
+	var view Matrix4
+	c.ViewMatrix(&view)
+	if got := view.TransformPoint(c.Target()); !vector3Close(got, Vector3{0, 0, -5}) {
+		t.Errorf("target in view space: expected=%v got=%v", Vector3{0, 0, -5}, got)
+	}
+
+	c.Rotate(0, 10)
+	if pitch := c.goal.pitch; pitch != DefaultPitchLimit {
+		t.Errorf("pitch clamp: expected=%v got=%v", DefaultPitchLimit, pitch)
+	}
+}
+
+func TestOrbitCameraLimits(t *testing.T) {
+	c := NewOrbitCamera(Vector3{}, 10, 0, 0)
+	c.MinDistance = 2
+	c.MaxDistance = 20
+	c.PanRadius = 1
+	c.Zoom(0.01)
+	c.Pan(100, 0)
+	c.Update(0)
+	if d := c.Distance(); d != 2 {
+		t.Errorf("distance: expected=2 got=%v", d)
+	}
+	if got, want := c.Target(), (Vector3{1, 0, 0}); !vector3Close(got, want) {
+		t.Errorf("target: expected=%v got=%v", want, got)
+	}
+}
+
+func TestFirstPersonCamera(t *testing.T) {
+	c := NewFirstPersonCamera(Vector3{}, math.Pi/2, 0)
+	c.Move(1, 0, 0)
+	c.Update(0)
+	if got, want := c.Position(), (Vector3{-1, 0, 0}); !vector3Close(got, want) {
+		t.Errorf("position: expected=%v got=%v", want, got)
+	}
+
+	c.Look(0, -10)
+	c.Update(0)
+	if _, pitch := c.Angles(); pitch != -DefaultPitchLimit {
+		t.Errorf("pitch clamp: expected=%v got=%v", -DefaultPitchLimit, pitch)
+	}
+
+	var view Matrix4
+	c.ViewMatrix(&view)
+	if got := view.TransformDirection(c.Forward()); !vector3Close(got, Vector3{0, 0, -1}) {
+		t.Errorf("forward in view space: expected=%v got=%v", Vector3{0, 0, -1}, got)
+	}
+}
+
+func TestFlyCamera(t *testing.T) {
+	c := NewFlyCamera(Vector3{}, NewQuaternionIdentity())
+	c.Rotate(0, math.Pi/2, 0) // look up
+	c.Move(2, 0, 0)
+	c.Update(0)
+	if got, want := c.Position(), (Vector3{0, 2, 0}); !vector3Close(got, want) {
+		t.Errorf("position: expected=%v got=%v", want, got)
+	}
+	if got, want := c.Up(), (Vector3{0, 0, 1}); !vector3Close(got, want) {
+		t.Errorf("up: expected=%v got=%v", want, got)
+	}
+}
+
+func TestCameraSmoothing(t *testing.T) {
+	c := NewFirstPersonCamera(Vector3{}, 0, 0)
+	c.Smoothing = 0.1
+	c.SetPosition(Vector3{10, 0, 0})
+	c.Update(0.1)
+	want := 10 * (1 - math.Exp(-1))
+	if got := c.Position().X; math.Abs(got-want) > 1e-9 {
+		t.Errorf("smoothed position: expected=%v got=%v", want, got)
+	}
+	for i := 0; i < 100; i++ {
+		c.Update(0.1)
+	}
+	if got := c.Position(); !vector3Close(got, Vector3{10, 0, 0}) {
+		t.Errorf("settled position: expected=%v got=%v", Vector3{10, 0, 0}, got)
+	}
+}
@@ -0,0 +1,112 @@
+package goglmath
+
+// Camera-relative rendering
+//
+// float32 has 24 bits of mantissa: at 10 km from the origin, positions are quantized to about 1 mm,
+// at 1000 km to about 6 cm. Combining world-space model and view matrices in float32 makes distant
+// objects jitter, even though their positions relative to the camera are small.
+//
+// CameraRelative keeps the world-space model and view transforms in float64 and composes
+// the model-view matrix after subtracting the camera position from both, so only the small
+// camera-relative offsets are reduced to float32 for upload:
+//
+// MV = V*M = Vrot * T(-eye) * M // Vrot = view rotation, with camera at origin
+//
+// FloatingOrigin moves the origin of a float32 local space towards the camera, for
+// applications keeping positions in float32 (for instance in TransformNode hierarchies).
+
+// CameraRelative composes model-view matrices relative to the camera position, in float64.
+type CameraRelative struct {
+	eye  Vec3[float64]
+	view Mat4[float64] // view rotation, with camera at origin
+}
+
+// NewCameraRelative creates the camera-relative composer.
+// See SetViewMatrix for focus, up and position.
+func NewCameraRelative(focus, up, position Vector3) *CameraRelative {
+	c := &CameraRelative{}
+	c.SetView(focus, up, position)
+	return c
+}
+
+// SetView sets the camera, with the same inputs as SetViewMatrix.
+func (c *CameraRelative) SetView(focus, up, position Vector3) {
+	c.eye = NewVec3[float64](position)
+	SetViewMat4(&c.view, NewVec3[float64](focus).Sub(c.eye), NewVec3[float64](up), Vec3[float64]{})
+}
+
+// Eye returns the camera position in world space.
+func (c *CameraRelative) Eye() Vector3 {
+	return c.eye.Vector3()
+}
+
+// ViewMatrix builds the view rotation matrix, with camera at origin.
+// Use it with positions already made camera-relative, see Relative.
+func (c *CameraRelative) ViewMatrix(viewMatrix *Matrix4) {
+	*viewMatrix = c.view.Matrix4()
+}
+
+// Relative returns the world position relative to the camera.
+func (c *CameraRelative) Relative(world Vector3) Vector3 {
+	return NewVec3[float64](world).Sub(c.eye).Vector3()
+}
+
+// ModelViewMatrix composes the model-view matrix V*M for the affine world-space model matrix.
+// The composition is done in float64, after subtracting the camera position from the model translation.
+func (c *CameraRelative) ModelViewMatrix(modelViewMatrix *Matrix4, model *Mat4[float64]) {
+	local := *model
+	local.data[12] -= c.eye.X
+	local.data[13] -= c.eye.Y
+	local.data[14] -= c.eye.Z
+	mv := c.view
+	mv.Multiply(&local)
+	*modelViewMatrix = mv.Matrix4()
+}
+
+// SetModelViewMatrix composes the model-view matrix for the model given with the same inputs as SetModelMatrix.
+// See ModelViewMatrix.
+func (c *CameraRelative) SetModelViewMatrix(modelViewMatrix *Matrix4, forward, up, translation Vector3) {
+	var model Mat4[float64]
+	SetModelMat4(&model, NewVec3[float64](forward), NewVec3[float64](up), NewVec3[float64](translation))
+	c.ModelViewMatrix(modelViewMatrix, &model)
+}
+
+// FloatingOrigin tracks the world position of a local space origin kept near the camera.
+//
+// Local positions are float32-friendly offsets from Origin.
+// When the camera moves farther than Threshold from the local origin,
+// Rebase moves the origin to the camera and reports the shift that
+// must be subtracted from every local position.
+type FloatingOrigin struct {
+	Origin    Vector3 // world position of the local origin
+	Threshold float64 // local camera distance that triggers rebasing
+}
+
+// ToLocal converts the world position to local space.
+func (f *FloatingOrigin) ToLocal(world Vector3) Vector3 {
+	return world.Sub(f.Origin)
+}
+
+// ToWorld converts the local position to world space.
+func (f *FloatingOrigin) ToWorld(local Vector3) Vector3 {
+	return f.Origin.Add(local)
+}
+
+// Rebase moves the origin to the camera, if the camera is farther than Threshold from the origin.
+// cameraLocal is the camera position in local space.
+// shift is the offset to subtract from every local position, including the camera.
+func (f *FloatingOrigin) Rebase(cameraLocal Vector3) (shift Vector3, rebased bool) {
+	if cameraLocal.Length() <= f.Threshold {
+		return Vector3{}, false
+	}
+	f.Origin = f.Origin.Add(cameraLocal)
+	return cameraLocal, true
+}
+
+// ShiftNodes subtracts the shift reported by Rebase from the local translation of the root nodes.
+// Descendants follow their roots.
+func ShiftNodes(shift Vector3, roots ...*TransformNode) {
+	for _, n := range roots {
+		n.SetTranslation(n.Translation().Sub(shift))
+	}
+}
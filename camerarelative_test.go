@@ -0,0 +1,67 @@
+package goglmath
+
+import (
+	"testing"
+)
+
+func TestCameraRelative(t *testing.T) {
+	eye := Vector3{6.4e6, 1000.25, -3e6} // planet radius scale
+	focus := eye.Add(Vector3{0, 0, -1})
+	up := Vector3{0, 1, 0}
+	object := eye.Add(Vector3{1.5, 0.375, -10.125})
+
+	c := NewCameraRelative(focus, up, eye)
+	var mv Matrix4
+	c.SetModelViewMatrix(&mv, Vector3{0, 0, -1}, up, object)
+	want := Vector3{1.5, 0.375, -10.125} // camera looks down -Z, no rotation
+	if got := mv.TransformPoint(Vector3{}); !vector3Close(got, want) {
+		t.Errorf("camera-relative: expected=%v got=%v", want, got)
+	}
+
+	// same composition in float32 world space loses the small offsets
+	var view, model Matrix4
+	SetViewMatrix(&view, focus.X, focus.Y, focus.Z, up.X, up.Y, up.Z, eye.X, eye.Y, eye.Z)
+	SetModelMatrix(&model, 0, 0, -1, 0, 1, 0, object.X, object.Y, object.Z)
+	view.Multiply(&model)
+	if got := view.TransformPoint(Vector3{}); got.Distance(want) < 0.01 {
+		t.Errorf("float32 world-space composition unexpectedly accurate: %v", got)
+	}
+
+	if got := c.Relative(object); !vector3Close(got, want) {
+		t.Errorf("relative: expected=%v got=%v", want, got)
+	}
+	var rot Matrix4
+	c.ViewMatrix(&rot)
+	if !rot.Identity() {
+		t.Errorf("view rotation: expected identity got=%v", rot)
+	}
+}
+
+func TestFloatingOrigin(t *testing.T) {
+	f := FloatingOrigin{Origin: Vector3{1e9, 0, 0}, Threshold: 1000}
+	if _, rebased := f.Rebase(Vector3{10, 0, 0}); rebased {
+		t.Errorf("rebased below threshold")
+	}
+
+	root := NewTransformNode()
+	root.SetTranslation(Vector3{2000, 5, 0})
+	child := NewTransformNode()
+	child.SetTranslation(Vector3{1, 0, 0})
+	child.SetParent(root)
+	worldBefore := f.ToWorld(child.WorldPosition())
+
+	shift, rebased := f.Rebase(Vector3{2000, 0, 0})
+	if !rebased || shift != (Vector3{2000, 0, 0}) {
+		t.Fatalf("rebase: expected shift=2000,0,0 got=%v,%v", shift, rebased)
+	}
+	ShiftNodes(shift, root)
+	if got := child.WorldPosition(); !vector3Close(got, Vector3{1, 5, 0}) {
+		t.Errorf("local after rebase: expected=%v got=%v", Vector3{1, 5, 0}, got)
+	}
+	if got := f.ToWorld(child.WorldPosition()); got != worldBefore {
+		t.Errorf("world after rebase: expected=%v got=%v", worldBefore, got)
+	}
+	if got := f.ToLocal(worldBefore); !vector3Close(got, Vector3{1, 5, 0}) {
+		t.Errorf("to local: got=%v", got)
+	}
+}
@@ -0,0 +1,183 @@
+package goglmath
+
+import (
+	"errors"
+	"math"
+)
+
+// Sentinel errors reported by the checked builders.
+// They are returned wrapped in an ArgumentError: test them with errors.Is.
+// On error, the matrix is left unchanged.
+var (
+	ErrNotFinite       = errors.New("argument is NaN or infinite")
+	ErrZeroVector      = errors.New("zero vector")
+	ErrNearNotPositive = errors.New("near plane distance is not positive")
+	ErrNearEqualsFar   = errors.New("near plane equals far plane")
+	ErrFarBeforeNear   = errors.New("far plane is closer than near plane")
+	ErrZeroAspect      = errors.New("zero aspect ratio")
+	ErrFieldOfView     = errors.New("field of view out of range (0,pi)")
+	ErrZeroExtent      = errors.New("zero width or height")
+)
+
+// ArgumentError reports which argument of a checked builder is invalid.
+//
+//	setPerspectiveMatrixChecked: zFar: argument is NaN or infinite
+type ArgumentError struct {
+	Func string // function name
+	Arg  string // argument name, or names for errors involving several arguments
+	Err  error  // one of the sentinel errors
+}
+
+func (e *ArgumentError) Error() string {
+	return e.Func + ": " + e.Arg + ": " + e.Err.Error()
+}
+
+// Unwrap returns the sentinel error, for errors.Is.
+func (e *ArgumentError) Unwrap() error {
+	return e.Err
+}
+
+// checkFinite reports ErrNotFinite for the first NaN or infinite value.
+func checkFinite(fn string, names []string, values ...float64) error {
+	for i, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return &ArgumentError{fn, names[i], ErrNotFinite}
+		}
+	}
+	return nil
+}
+
+// Normalize3Checked calculates a normalized copy of a vector.
+// Unlike Normalize3, ErrZeroVector is reported for zero (or non-finite) vectors.
+func Normalize3Checked(x, y, z float64) (float64, float64, float64, error) {
+	length := Length3(x, y, z)
+	if !(length > 0) || math.IsInf(length, 0) {
+		return x, y, z, &ArgumentError{"normalize3Checked", "x, y, z", ErrZeroVector}
+	}
+	return x / length, y / length, z / length, nil
+}
+
+// checkedAxes returns unit forward and unit up orthogonal to forward.
+// If up is zero or parallel to forward, an alternate up axis is used and fellBack is true.
+// ok is false if forward is zero.
+func checkedAxes(forward, up Vector3) (f, u Vector3, fellBack, ok bool) {
+	length := forward.Length()
+	if !(length > 0) || math.IsInf(length, 0) {
+		return forward, up, false, false
+	}
+	f = forward.Scale(1 / length)
+
+	right := f.Cross(up)
+	if length := right.Length(); !(length > 0.000001*up.Length()) {
+		fellBack = true
+		right = f.Cross(alternateUp(f))
+	}
+	right = right.Normalize()
+	u = right.Cross(f)
+	return f, u, fellBack, true
+}
+
+// alternateUp returns an up axis not parallel to the unit forward vector.
+// Usually +Y. When looking straight down (or up), up is -Z (or +Z), as if the default camera had been pitched.
+func alternateUp(f Vector3) Vector3 {
+	if math.Abs(f.Y) < 0.9 {
+		return Vector3{0, 1, 0}
+	}
+	if f.Y < 0 {
+		return Vector3{0, 0, -1}
+	}
+	return Vector3{0, 0, 1}
+}
+
+// SetViewMatrixChecked builds the view matrix, as SetViewMatrix, validating the inputs.
+// If up is zero or parallel to the view direction, an alternate up axis is used and fellBack is true;
+// the matrix is valid and err is nil.
+//
+// ErrNotFinite: an argument is NaN or infinite.
+// ErrZeroVector: focus equals position.
+func SetViewMatrixChecked(viewMatrix *Matrix4, focusX, focusY, focusZ, upX, upY, upZ, posX, posY, posZ float64) (fellBack bool, err error) {
+	const fn = "setViewMatrixChecked"
+	names := []string{"focusX", "focusY", "focusZ", "upX", "upY", "upZ", "posX", "posY", "posZ"}
+	if err := checkFinite(fn, names, focusX, focusY, focusZ, upX, upY, upZ, posX, posY, posZ); err != nil {
+		return false, err
+	}
+	forward := Vector3{focusX - posX, focusY - posY, focusZ - posZ}
+	_, up, fellBack, ok := checkedAxes(forward, Vector3{upX, upY, upZ})
+	if !ok {
+		return false, &ArgumentError{fn, "focus, pos", ErrZeroVector}
+	}
+	SetViewMatrix(viewMatrix, focusX, focusY, focusZ, up.X, up.Y, up.Z, posX, posY, posZ)
+	return fellBack, nil
+}
+
+// SetModelMatrixChecked builds the model matrix, as SetModelMatrix, validating the inputs.
+// Unlike SetModelMatrix, forward is normalized and up is made orthogonal to forward.
+// If up is zero or parallel to forward, an alternate up axis is used and fellBack is true;
+// the matrix is valid and err is nil.
+//
+// ErrNotFinite: an argument is NaN or infinite.
+// ErrZeroVector: forward is zero.
+func SetModelMatrixChecked(modelMatrix *Matrix4, forwardX, forwardY, forwardZ, upX, upY, upZ, tX, tY, tZ float64) (fellBack bool, err error) {
+	const fn = "setModelMatrixChecked"
+	names := []string{"forwardX", "forwardY", "forwardZ", "upX", "upY", "upZ", "tX", "tY", "tZ"}
+	if err := checkFinite(fn, names, forwardX, forwardY, forwardZ, upX, upY, upZ, tX, tY, tZ); err != nil {
+		return false, err
+	}
+	f, u, fellBack, ok := checkedAxes(Vector3{forwardX, forwardY, forwardZ}, Vector3{upX, upY, upZ})
+	if !ok {
+		return false, &ArgumentError{fn, "forward", ErrZeroVector}
+	}
+	SetModelMatrix(modelMatrix, f.X, f.Y, f.Z, u.X, u.Y, u.Z, tX, tY, tZ)
+	return fellBack, nil
+}
+
+// SetRotationMatrixChecked builds the rotation matrix, as SetRotationMatrix, validating the inputs.
+// See SetModelMatrixChecked.
+func SetRotationMatrixChecked(rotationMatrix *Matrix4, forwardX, forwardY, forwardZ, upX, upY, upZ float64) (fellBack bool, err error) {
+	return SetModelMatrixChecked(rotationMatrix, forwardX, forwardY, forwardZ, upX, upY, upZ, 0, 0, 0)
+}
+
+// SetPerspectiveMatrixChecked builds the perspective projection matrix, as SetPerspectiveMatrix, validating the inputs.
+// zFar must be finite: use SetPerspectiveMatrixInfinite for an infinite far plane.
+// zFar must be beyond zNear: for reversed depth, use SetPerspectiveMatrixReverseZ.
+func SetPerspectiveMatrixChecked(perspectiveMatrix *Matrix4, fieldOfViewYRadians, aspectRatio, zNear, zFar float64) error {
+	const fn = "setPerspectiveMatrixChecked"
+	names := []string{"fieldOfViewYRadians", "aspectRatio", "zNear", "zFar"}
+	if err := checkFinite(fn, names, fieldOfViewYRadians, aspectRatio, zNear, zFar); err != nil {
+		return err
+	}
+	switch {
+	case !(fieldOfViewYRadians > 0 && fieldOfViewYRadians < math.Pi):
+		return &ArgumentError{fn, "fieldOfViewYRadians", ErrFieldOfView}
+	case aspectRatio == 0:
+		return &ArgumentError{fn, "aspectRatio", ErrZeroAspect}
+	case !(zNear > 0):
+		return &ArgumentError{fn, "zNear", ErrNearNotPositive}
+	case zNear == zFar:
+		return &ArgumentError{fn, "zNear, zFar", ErrNearEqualsFar}
+	case zFar < zNear:
+		return &ArgumentError{fn, "zNear, zFar", ErrFarBeforeNear}
+	}
+	SetPerspectiveMatrix(perspectiveMatrix, fieldOfViewYRadians, aspectRatio, zNear, zFar)
+	return nil
+}
+
+// SetOrthoMatrixChecked builds the orthographic projection matrix, as SetOrthoMatrix, validating the inputs.
+// Negative near, and far closer than near, are valid for orthographic projections.
+func SetOrthoMatrixChecked(orthoMatrix *Matrix4, left, right, bottom, top, near, far float64) error {
+	const fn = "setOrthoMatrixChecked"
+	names := []string{"left", "right", "bottom", "top", "near", "far"}
+	if err := checkFinite(fn, names, left, right, bottom, top, near, far); err != nil {
+		return err
+	}
+	switch {
+	case left == right:
+		return &ArgumentError{fn, "left, right", ErrZeroExtent}
+	case bottom == top:
+		return &ArgumentError{fn, "bottom, top", ErrZeroExtent}
+	case near == far:
+		return &ArgumentError{fn, "near, far", ErrNearEqualsFar}
+	}
+	SetOrthoMatrix(orthoMatrix, left, right, bottom, top, near, far)
+	return nil
+}
@@ -0,0 +1,142 @@
+package goglmath
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func matrix4HasNaN(m *Matrix4) bool {
+	for _, v := range m.data {
+		if math.IsNaN(float64(v)) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSetViewMatrixChecked(t *testing.T) {
+	var want, got Matrix4
+	SetViewMatrix(&want, 0, 0, 0, 0, 1, 0, 1, 2, 3)
+	if fellBack, err := SetViewMatrixChecked(&got, 0, 0, 0, 0, 1, 0, 1, 2, 3); fellBack || err != nil || !matrix4Close(&got, &want, 0.000001) {
+		t.Errorf("valid input: expected=%v got=%v fellBack=%v err=%v", want, got, fellBack, err)
+	}
+
+	for _, test := range []struct {
+		name  string
+		input [9]float64
+		want  error
+	}{
+		{"focus equals position", [9]float64{1, 2, 3, 0, 1, 0, 1, 2, 3}, ErrZeroVector},
+		{"NaN focus", [9]float64{math.NaN(), 0, 0, 0, 1, 0, 1, 2, 3}, ErrNotFinite},
+		{"infinite up", [9]float64{0, 0, 0, 0, math.Inf(1), 0, 1, 2, 3}, ErrNotFinite},
+		{"NaN position", [9]float64{0, 0, 0, 0, 1, 0, 1, 2, math.NaN()}, ErrNotFinite},
+	} {
+		got = NewMatrix4Identity()
+		in := test.input
+		if _, err := SetViewMatrixChecked(&got, in[0], in[1], in[2], in[3], in[4], in[5], in[6], in[7], in[8]); !errors.Is(err, test.want) {
+			t.Errorf("%s: expected=%v got=%v", test.name, test.want, err)
+		}
+		if !got.Identity() {
+			t.Errorf("%s: matrix changed on error: %v", test.name, got)
+		}
+	}
+
+	// looking straight down with up +Y
+	fellBack, err := SetViewMatrixChecked(&got, 0, 0, 0, 0, 1, 0, 0, 10, 0)
+	if !fellBack || err != nil {
+		t.Errorf("parallel up: expected fallback without error, got fellBack=%v err=%v", fellBack, err)
+	}
+	if matrix4HasNaN(&got) || !got.IsRigid() {
+		t.Errorf("parallel up: bad fallback matrix: %v", got)
+	}
+	if p := got.TransformPoint(Vector3{}); !vector3Close(p, Vector3{0, 0, -10}) {
+		t.Errorf("parallel up: expected origin at 0,0,-10 got=%v", p)
+	}
+	if p := got.TransformPoint(Vector3{0, 0, -1}); p.Y <= 0 {
+		t.Errorf("looking down: -Z expected at screen top, got=%v", p)
+	}
+}
+
+func TestSetModelMatrixChecked(t *testing.T) {
+	var want, got Matrix4
+	SetModelMatrix(&want, 0, 0, -1, 0, 1, 0, 4, 5, 6)
+	if fellBack, err := SetModelMatrixChecked(&got, 0, 0, -2, 0, 3, 0.5, 4, 5, 6); fellBack || err != nil || !matrix4Close(&got, &want, 0.000001) {
+		t.Errorf("unnormalized input: expected=%v got=%v fellBack=%v err=%v", want, got, fellBack, err)
+	}
+
+	if fellBack, err := SetRotationMatrixChecked(&got, 1, 0, 0, 0, 0, 0); !fellBack || err != nil {
+		t.Errorf("zero up: expected fallback without error, got fellBack=%v err=%v", fellBack, err)
+	}
+	if matrix4HasNaN(&got) || !got.IsRigid() {
+		t.Errorf("zero up: bad fallback matrix: %v", got)
+	}
+
+	got = NewMatrix4Identity()
+	if _, err := SetRotationMatrixChecked(&got, 0, 0, 0, 0, 1, 0); !errors.Is(err, ErrZeroVector) {
+		t.Errorf("zero forward: expected=%v got=%v", ErrZeroVector, err)
+	}
+	if _, err := SetModelMatrixChecked(&got, 0, 0, -1, 0, 1, 0, math.Inf(-1), 0, 0); !errors.Is(err, ErrNotFinite) {
+		t.Errorf("infinite translation: expected=%v got=%v", ErrNotFinite, err)
+	}
+	if _, err := SetRotationMatrixChecked(&got, 0, 0, -1, math.NaN(), 1, 0); !errors.Is(err, ErrNotFinite) {
+		t.Errorf("NaN up: expected=%v got=%v", ErrNotFinite, err)
+	}
+	if !got.Identity() {
+		t.Errorf("matrix changed on error: %v", got)
+	}
+
+	if _, _, _, err := Normalize3Checked(0, 0, 0); !errors.Is(err, ErrZeroVector) {
+		t.Errorf("normalize zero: expected=%v got=%v", ErrZeroVector, err)
+	}
+}
+
+func TestSetProjectionChecked(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	var m Matrix4
+	tests := []struct {
+		name string
+		set  func(*Matrix4) error
+		want error
+	}{
+		{"valid perspective", func(m *Matrix4) error { return SetPerspectiveMatrixChecked(m, 1, 1.5, 0.1, 100) }, nil},
+		{"zero fov", func(m *Matrix4) error { return SetPerspectiveMatrixChecked(m, 0, 1.5, 0.1, 100) }, ErrFieldOfView},
+		{"NaN fov", func(m *Matrix4) error { return SetPerspectiveMatrixChecked(m, nan, 1.5, 0.1, 100) }, ErrNotFinite},
+		{"zero aspect", func(m *Matrix4) error { return SetPerspectiveMatrixChecked(m, 1, 0, 0.1, 100) }, ErrZeroAspect},
+		{"NaN aspect", func(m *Matrix4) error { return SetPerspectiveMatrixChecked(m, 1, nan, 0.1, 100) }, ErrNotFinite},
+		{"infinite aspect", func(m *Matrix4) error { return SetPerspectiveMatrixChecked(m, 1, inf, 0.1, 100) }, ErrNotFinite},
+		{"zero near", func(m *Matrix4) error { return SetPerspectiveMatrixChecked(m, 1, 1.5, 0, 100) }, ErrNearNotPositive},
+		{"infinite near", func(m *Matrix4) error { return SetPerspectiveMatrixChecked(m, 1, 1.5, inf, 100) }, ErrNotFinite},
+		{"near equals far", func(m *Matrix4) error { return SetPerspectiveMatrixChecked(m, 1, 1.5, 5, 5) }, ErrNearEqualsFar},
+		{"far before near", func(m *Matrix4) error { return SetPerspectiveMatrixChecked(m, 1, 1.5, 100, 0.1) }, ErrFarBeforeNear},
+		{"infinite far", func(m *Matrix4) error { return SetPerspectiveMatrixChecked(m, 1, 1.5, 0.1, inf) }, ErrNotFinite},
+		{"NaN far", func(m *Matrix4) error { return SetPerspectiveMatrixChecked(m, 1, 1.5, 0.1, nan) }, ErrNotFinite},
+		{"valid ortho", func(m *Matrix4) error { return SetOrthoMatrixChecked(m, -1, 1, -1, 1, -1, 1) }, nil},
+		{"zero width", func(m *Matrix4) error { return SetOrthoMatrixChecked(m, 1, 1, -1, 1, -1, 1) }, ErrZeroExtent},
+		{"ortho near equals far", func(m *Matrix4) error { return SetOrthoMatrixChecked(m, -1, 1, -1, 1, 1, 1) }, ErrNearEqualsFar},
+		{"infinite ortho left", func(m *Matrix4) error { return SetOrthoMatrixChecked(m, -inf, 1, -1, 1, -1, 1) }, ErrNotFinite},
+		{"NaN ortho top", func(m *Matrix4) error { return SetOrthoMatrixChecked(m, -1, 1, -1, nan, -1, 1) }, ErrNotFinite},
+		{"infinite ortho far", func(m *Matrix4) error { return SetOrthoMatrixChecked(m, -1, 1, -1, 1, -1, inf) }, ErrNotFinite},
+	}
+	err := SetPerspectiveMatrixChecked(&m, 1, 1.5, 0.1, inf)
+	var argErr *ArgumentError
+	if !errors.As(err, &argErr) || argErr.Arg != "zFar" || argErr.Err != ErrNotFinite {
+		t.Errorf("argument error: expected zFar, got=%#v", err)
+	}
+	if want := "setPerspectiveMatrixChecked: zFar: argument is NaN or infinite"; err.Error() != want {
+		t.Errorf("message: expected=%q got=%q", want, err.Error())
+	}
+
+	for _, test := range tests {
+		m = NewMatrix4Identity()
+		if err := test.set(&m); !errors.Is(err, test.want) {
+			t.Errorf("%s: expected=%v got=%v", test.name, test.want, err)
+		}
+		if test.want != nil && !m.Identity() {
+			t.Errorf("%s: matrix changed on error: %v", test.name, m)
+		}
+		if test.want == nil && matrix4HasNaN(&m) {
+			t.Errorf("%s: NaN in matrix: %v", test.name, m)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package goglmath
+
+import (
+	"math"
+)
+
+// classifyEpsilon is the tolerance used by the matrix classifiers.
+// It is well above float32 rounding for matrices built by this package.
+const classifyEpsilon = 0.00001
+
+// singularConditionNumber is the condition number above which IsSingular reports the matrix as singular.
+// Inverting such matrices in float32 loses nearly all significant digits.
+const singularConditionNumber = 1e6
+
+// rigidInverseEpsilon is the orthonormality tolerance for CopyInverseAutoFrom to use CopyRigidInverseFrom.
+// It is a few float32 ULPs, so the transpose differs from the true inverse only by rounding.
+const rigidInverseEpsilon = 0.000001
+
+// Matrix4EqualAbs checks if two matrices are equal within absolute tolerance.
+// |a-b| <= tolerance for every element
+func Matrix4EqualAbs(m1, m2 *Matrix4, tolerance float64) bool {
+	for i, v := range m1.data {
+		if !(math.Abs(float64(v)-float64(m2.data[i])) <= tolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matrix4EqualRel checks if two matrices are equal within relative tolerance.
+// |a-b| <= tolerance * max(|a|,|b|) for every element
+// Elements near zero need an absolute tolerance, see Matrix4EqualAbs.
+func Matrix4EqualRel(m1, m2 *Matrix4, tolerance float64) bool {
+	for i, v := range m1.data {
+		a := float64(v)
+		b := float64(m2.data[i])
+		if !(math.Abs(a-b) <= tolerance*math.Max(math.Abs(a), math.Abs(b))) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matrix4EqualULP checks if two matrices are equal within maxULP units in the last place.
+// Zeros of either sign are equal. NaN is never equal.
+func Matrix4EqualULP(m1, m2 *Matrix4, maxULP int) bool {
+	for i, v := range m1.data {
+		if ulpDistance(v, m2.data[i]) > int64(maxULP) {
+			return false
+		}
+	}
+	return true
+}
+
+// ulpDistance returns how many representable float32 values lie between a and b.
+func ulpDistance(a, b float32) int64 {
+	if a != a || b != b {
+		return math.MaxInt64 // NaN
+	}
+	d := orderedBits(a) - orderedBits(b)
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// orderedBits maps float32 to integers preserving order, with both zeros mapped to 0.
+func orderedBits(f float32) int64 {
+	bits := int64(math.Float32bits(f) & 0x7fffffff)
+	if math.Signbit(float64(f)) {
+		return -bits
+	}
+	return bits
+}
+
+// NearIdentity checks if the matrix is identity within absolute tolerance.
+func (m *Matrix4) NearIdentity(tolerance float64) bool {
+	return Matrix4EqualAbs(m, &mat4identity, tolerance)
+}
+
+// IsAffine reports if the last row is 0 0 0 1, within classifyEpsilon.
+// Affine matrices preserve parallel lines: model and view matrices are affine.
+func (m *Matrix4) IsAffine() bool {
+	return math.Abs(float64(m.data[3])) < classifyEpsilon &&
+		math.Abs(float64(m.data[7])) < classifyEpsilon &&
+		math.Abs(float64(m.data[11])) < classifyEpsilon &&
+		math.Abs(float64(m.data[15])-1) < classifyEpsilon
+}
+
+// affine reports if the last row is exactly 0 0 0 1.
+// Unlike IsAffine, it is safe for choosing CopyAffineInverseFrom.
+func (m *Matrix4) affine() bool {
+	return m.data[3] == 0 && m.data[7] == 0 && m.data[11] == 0 && m.data[15] == 1
+}
+
+func (m *Matrix4) upperColumns() (c0, c1, c2 Vector3) {
+	c0 = Vector3{float64(m.data[0]), float64(m.data[1]), float64(m.data[2])}
+	c1 = Vector3{float64(m.data[4]), float64(m.data[5]), float64(m.data[6])}
+	c2 = Vector3{float64(m.data[8]), float64(m.data[9]), float64(m.data[10])}
+	return
+}
+
+// IsOrthonormal reports if the columns of the upper 3x3 are orthogonal unit vectors, within classifyEpsilon.
+// Rotations and reflections are orthonormal.
+func (m *Matrix4) IsOrthonormal() bool {
+	return m.orthonormal(classifyEpsilon)
+}
+
+func (m *Matrix4) orthonormal(epsilon float64) bool {
+	c0, c1, c2 := m.upperColumns()
+	return math.Abs(c0.Dot(c0)-1) < epsilon &&
+		math.Abs(c1.Dot(c1)-1) < epsilon &&
+		math.Abs(c2.Dot(c2)-1) < epsilon &&
+		math.Abs(c0.Dot(c1)) < epsilon &&
+		math.Abs(c0.Dot(c2)) < epsilon &&
+		math.Abs(c1.Dot(c2)) < epsilon
+}
+
+// IsRigid reports if the matrix is a rotation followed by translation, within classifyEpsilon.
+// Rigid matrices are affine, orthonormal and do not mirror.
+// Matrices built by SetViewMatrix are rigid.
+func (m *Matrix4) IsRigid() bool {
+	return m.IsAffine() && m.orthonormal(classifyEpsilon) && m.rotation()
+}
+
+// rigid reports if the matrix is exactly affine and orthonormal within rigidInverseEpsilon.
+// Unlike IsRigid, it is safe for choosing CopyRigidInverseFrom.
+func (m *Matrix4) rigid() bool {
+	return m.affine() && m.orthonormal(rigidInverseEpsilon) && m.rotation()
+}
+
+// rotation reports if the upper 3x3 does not mirror.
+func (m *Matrix4) rotation() bool {
+	c0, c1, c2 := m.upperColumns()
+	return c0.Cross(c1).Dot(c2) > 0
+}
+
+// HasUniformScale reports if the upper 3x3 is a rotation (or reflection) scaled equally along all axes, within classifyEpsilon relative to the scale.
+// Normals transformed by matrices with uniform scale need only renormalization, not the normal matrix.
+func (m *Matrix4) HasUniformScale() bool {
+	c0, c1, c2 := m.upperColumns()
+	s0 := c0.Dot(c0)
+	if s0 == 0 {
+		return false
+	}
+	return math.Abs(c1.Dot(c1)-s0) < classifyEpsilon*s0 &&
+		math.Abs(c2.Dot(c2)-s0) < classifyEpsilon*s0 &&
+		math.Abs(c0.Dot(c1)) < classifyEpsilon*s0 &&
+		math.Abs(c0.Dot(c2)) < classifyEpsilon*s0 &&
+		math.Abs(c1.Dot(c2)) < classifyEpsilon*s0
+}
+
+// IsPerspective reports if the projection matrix performs perspective division:
+// clip w depends on the position, as in matrices built by SetPerspectiveMatrix.
+// The classification holds for projection matrices combined with view and model matrices.
+func (m *Matrix4) IsPerspective() bool {
+	return math.Abs(float64(m.data[3])) >= classifyEpsilon ||
+		math.Abs(float64(m.data[7])) >= classifyEpsilon ||
+		math.Abs(float64(m.data[11])) >= classifyEpsilon
+}
+
+// IsOrthographic reports if the matrix is a parallel projection, as built by SetOrthoMatrix, within classifyEpsilon:
+// the last row is 0 0 0 1, the upper 3x3 only scales (no rotation or shear) and depth is not flattened.
+// Unlike IsPerspective, the classification does not hold once combined with view and model matrices.
+func (m *Matrix4) IsOrthographic() bool {
+	if !m.IsAffine() {
+		return false
+	}
+	for _, i := range [...]int{1, 2, 4, 6, 8, 9} {
+		if math.Abs(float64(m.data[i])) >= classifyEpsilon {
+			return false
+		}
+	}
+	return math.Abs(float64(m.data[10])) >= classifyEpsilon
+}
+
+// IsSingular reports if the matrix is singular, or too ill-conditioned to be inverted in float32 precision.
+// See ConditionNumber.
+func (m *Matrix4) IsSingular() bool {
+	return m.ConditionNumber() > singularConditionNumber
+}
+
+// ConditionNumber estimates how much the matrix amplifies relative errors when inverted.
+// The result is computed in the 1-norm: ||m|| * ||inverse(m)||
+// Well-conditioned matrices have condition number near 1.
+// Singular matrices, and matrices with NaN or infinite elements, have +Inf.
+func (m *Matrix4) ConditionNumber() float64 {
+	var a [4][8]float64 // augmented [m | I], row-major
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			v := float64(m.data[c*4+r])
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return math.Inf(1)
+			}
+			a[r][c] = v
+		}
+		a[r][4+r] = 1
+	}
+	norm := norm1(&a, 0)
+
+	// Gauss-Jordan elimination with partial pivoting
+	for c := 0; c < 4; c++ {
+		pivot := c
+		for r := c + 1; r < 4; r++ {
+			if math.Abs(a[r][c]) > math.Abs(a[pivot][c]) {
+				pivot = r
+			}
+		}
+		if a[pivot][c] == 0 {
+			return math.Inf(1)
+		}
+		a[c], a[pivot] = a[pivot], a[c]
+		p := a[c][c]
+		for k := range a[c] {
+			a[c][k] /= p
+		}
+		for r := 0; r < 4; r++ {
+			if r == c {
+				continue
+			}
+			f := a[r][c]
+			for k := range a[r] {
+				a[r][k] -= f * a[c][k]
+			}
+		}
+	}
+
+	return norm * norm1(&a, 4)
+}
+
+// norm1 returns the maximum absolute column sum of the 4x4 block starting at column first.
+func norm1(a *[4][8]float64, first int) float64 {
+	var max float64
+	for c := first; c < first+4; c++ {
+		var sum float64
+		for r := 0; r < 4; r++ {
+			sum += math.Abs(a[r][c])
+		}
+		if sum > max {
+			max = sum
+		}
+	}
+	return max
+}
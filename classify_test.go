@@ -0,0 +1,147 @@
+package goglmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMatrix4EqualTolerance(t *testing.T) {
+	var m Matrix4
+	SetModelMatrix(&m, 0, 0.6, -0.8, 1, 0, 0, 7, -8, 9)
+	m.Scale(3, 1, 2, 1)
+	twice := m
+	twice.Invert()
+	twice.Invert()
+
+	if !Matrix4EqualAbs(&m, &twice, 0.0001) {
+		t.Errorf("abs: expected=%v got=%v", m, twice)
+	}
+	if !Matrix4EqualULP(&m, &twice, 64) {
+		t.Errorf("ulp: expected=%v got=%v", m, twice)
+	}
+
+	a := NewMatrix4Identity()
+	b := a
+	b.data[0] = math.Nextafter32(1, 2)
+	if !Matrix4EqualULP(&a, &b, 1) || Matrix4EqualULP(&a, &b, 0) {
+		t.Errorf("ulp: one step apart")
+	}
+	b.data[0] = 1.001
+	if Matrix4EqualRel(&a, &b, 0.0001) || !Matrix4EqualRel(&a, &b, 0.01) {
+		t.Errorf("rel: 1.001 vs 1")
+	}
+
+	var z1, z2 Matrix4
+	z2.data[5] = float32(math.Copysign(0, -1))
+	if !Matrix4EqualULP(&z1, &z2, 0) {
+		t.Errorf("ulp: negative zero")
+	}
+	z2.data[5] = float32(math.NaN())
+	if Matrix4EqualULP(&z2, &z2, 1000) || Matrix4EqualAbs(&z2, &z2, 1) {
+		t.Errorf("NaN reported equal")
+	}
+
+	prod := m
+	prod.Multiply(&twice)
+	inv := twice
+	inv.Invert()
+	prod.Multiply(&inv)
+	prod.Invert()
+	prod.Multiply(&m)
+	if !prod.NearIdentity(0.0001) {
+		t.Errorf("near identity: %v", prod)
+	}
+}
+
+func TestMatrix4Classify(t *testing.T) {
+	var view, model, persp, ortho Matrix4
+	SetViewMatrix(&view, 1, 2, 3, 0, 1, 0, -4, 5, 6)
+	SetModelMatrix(&model, 0, 0.6, -0.8, 1, 0, 0, 7, -8, 9)
+	SetPerspectiveMatrix(&persp, math.Pi/2, 1, 1, 10)
+	SetOrthoMatrix(&ortho, -1, 1, -1, 1, 1, 10)
+	uniform := model
+	uniform.Scale(2, 2, 2, 1)
+	mirror := model
+	mirror.Scale(-1, 1, 1, 1)
+	stretch := model
+	stretch.Scale(1, 3, 1, 1)
+	scale := NewMatrix4Identity()
+	scale.Scale(2, 3, 4, 1)
+	identity := NewMatrix4Identity()
+
+	tests := []struct {
+		name                                     string
+		m                                        Matrix4
+		affine, orthonormal, rigid, uniformScale bool
+		perspective, orthographic                bool
+	}{
+		{"identity", identity, true, true, true, true, false, true},
+		{"view", view, true, true, true, true, false, false},
+		{"model", model, true, true, true, true, false, false},
+		{"uniform", uniform, true, false, false, true, false, false},
+		{"mirror", mirror, true, true, false, true, false, false},
+		{"stretch", stretch, true, false, false, false, false, false},
+		{"scale", scale, true, false, false, false, false, true},
+		{"perspective", persp, false, false, false, false, true, false},
+		{"ortho", ortho, true, false, false, false, false, true},
+	}
+	for _, test := range tests {
+		m := test.m
+		if got := m.IsAffine(); got != test.affine {
+			t.Errorf("%s: affine: expected=%v got=%v", test.name, test.affine, got)
+		}
+		if got := m.IsOrthonormal(); got != test.orthonormal {
+			t.Errorf("%s: orthonormal: expected=%v got=%v", test.name, test.orthonormal, got)
+		}
+		if got := m.IsRigid(); got != test.rigid {
+			t.Errorf("%s: rigid: expected=%v got=%v", test.name, test.rigid, got)
+		}
+		if got := m.HasUniformScale(); got != test.uniformScale {
+			t.Errorf("%s: uniform scale: expected=%v got=%v", test.name, test.uniformScale, got)
+		}
+		if got := m.IsPerspective(); got != test.perspective {
+			t.Errorf("%s: perspective: expected=%v got=%v", test.name, test.perspective, got)
+		}
+		if got := m.IsOrthographic(); got != test.orthographic {
+			t.Errorf("%s: orthographic: expected=%v got=%v", test.name, test.orthographic, got)
+		}
+		if m.IsSingular() {
+			t.Errorf("%s: reported singular", test.name)
+		}
+	}
+}
+
+func TestIsOrthographicFlat(t *testing.T) {
+	var ortho Matrix4
+	SetOrthoMatrix(&ortho, -1, 1, -1, 1, 1, 10)
+	ortho.data[10] = 0
+	if ortho.IsOrthographic() {
+		t.Errorf("flattened depth reported orthographic")
+	}
+}
+
+func TestConditionNumber(t *testing.T) {
+	id := NewMatrix4Identity()
+	if c := id.ConditionNumber(); c != 1 {
+		t.Errorf("identity: expected=1 got=%v", c)
+	}
+
+	s := NewMatrix4Identity()
+	s.Scale(1, 1, 1e-7, 1)
+	if !s.IsSingular() {
+		t.Errorf("ill-conditioned matrix not reported singular: cond=%v", s.ConditionNumber())
+	}
+
+	var null Matrix4
+	if c := null.ConditionNumber(); !math.IsInf(c, 1) {
+		t.Errorf("null: expected=+Inf got=%v", c)
+	}
+
+	for _, bad := range []float32{float32(math.NaN()), float32(math.Inf(-1))} {
+		m := NewMatrix4Identity()
+		m.data[6] = bad
+		if c := m.ConditionNumber(); !math.IsInf(c, 1) || !m.IsSingular() {
+			t.Errorf("element %v: expected=+Inf got=%v", bad, c)
+		}
+	}
+}
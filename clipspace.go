@@ -0,0 +1,26 @@
+package goglmath
+
+// ClipSpace describes the clip space convention expected by a graphics API.
+//
+// OpenGL: NDC depth ranges from -1 (near) to 1 (far), NDC +Y points up.
+// Vulkan: NDC depth ranges from 0 (near) to 1 (far), NDC +Y points down.
+// Metal, WebGPU, Direct3D: NDC depth ranges from 0 (near) to 1 (far), NDC +Y points up.
+//
+// ReverseZ maps the near plane to the maximum NDC depth and the far plane to the minimum.
+// Combined with ZeroToOneDepth and a floating-point depth buffer, reverse-Z greatly reduces z-fighting.
+// Reverse-Z requires depth test GREATER and depth buffer cleared to 0.
+type ClipSpace struct {
+	ZeroToOneDepth bool // NDC depth ranges 0..1 instead of -1..1
+	FlipY          bool // NDC +Y points down instead of up
+	ReverseZ       bool // near plane maps to NDC depth 1, far plane maps to NDC depth 0 (or -1)
+}
+
+// Clip space conventions for common graphics APIs.
+var (
+	ClipSpaceOpenGL   = ClipSpace{}
+	ClipSpaceVulkan   = ClipSpace{ZeroToOneDepth: true, FlipY: true}
+	ClipSpaceMetal    = ClipSpace{ZeroToOneDepth: true}
+	ClipSpaceWebGPU   = ClipSpace{ZeroToOneDepth: true}
+	ClipSpaceDirect3D = ClipSpace{ZeroToOneDepth: true}
+	ClipSpaceReverseZ = ClipSpace{ZeroToOneDepth: true, ReverseZ: true} // Metal, WebGPU, Direct3D or OpenGL with glClipControl(GL_LOWER_LEFT, GL_ZERO_TO_ONE)
+)
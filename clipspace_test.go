@@ -0,0 +1,198 @@
+package goglmath
+
+import (
+	"math"
+	"testing"
+)
+
+var testClipSpaces = []struct {
+	name string
+	clip ClipSpace
+}{
+	{"opengl", ClipSpaceOpenGL},
+	{"vulkan", ClipSpaceVulkan},
+	{"webgpu", ClipSpaceWebGPU},
+}
+
+func TestPerspectiveMatrixClipDepth(t *testing.T) {
+	var P Matrix4
+	SetPerspectiveMatrixClip(&P, ClipSpaceWebGPU, math.Pi/2, 1, 1, 10)
+	near := P.TransformPoint(Vector3{0, 0, -1})
+	far := P.TransformPoint(Vector3{0, 0, -10})
+	if !closeToZero(near.Z) || !closeToZero(far.Z-1) {
+		t.Errorf("NDC depth: expected near=0 far=1 got near=%v far=%v", near.Z, far.Z)
+	}
+
+	SetPerspectiveMatrixClip(&P, ClipSpaceVulkan, math.Pi/2, 1, 1, 10)
+	if up := P.TransformPoint(Vector3{0, 1, -2}); up.Y >= 0 {
+		t.Errorf("vulkan: expected NDC Y down, got=%v", up.Y)
+	}
+}
+
+func TestOrthoMatrixClipDepth(t *testing.T) {
+	var O Matrix4
+	SetOrthoMatrixClip(&O, ClipSpaceMetal, -1, 1, -1, 1, 2, 4)
+	near := O.TransformPoint(Vector3{0, 0, -2})
+	far := O.TransformPoint(Vector3{0, 0, -4})
+	if !closeToZero(near.Z) || !closeToZero(far.Z-1) {
+		t.Errorf("NDC depth: expected near=0 far=1 got near=%v far=%v", near.Z, far.Z)
+	}
+}
+
+func TestProjectClip(t *testing.T) {
+	for _, cs := range testClipSpaces {
+		var P Matrix4
+		SetPerspectiveMatrixClip(&P, cs.clip, math.Pi/2, 1, 1, 10)
+
+		// point above view axis is drawn on the upper half of the window
+		_, winY, depth, err := ProjectClip(&P, cs.clip, 0, 100, 0, 100, 0, 1, OriginTopLeft, 0, 1, -2)
+		if err != nil {
+			t.Fatalf("%s: project: %v", cs.name, err)
+		}
+		if winY >= 50 {
+			t.Errorf("%s: expected upper half, got winY=%v", cs.name, winY)
+		}
+
+		x, y, z, err := UnprojectClip(&P, cs.clip, 0, 100, 0, 100, 0, 1, OriginTopLeft, 50, winY, depth)
+		if err != nil {
+			t.Fatalf("%s: unproject: %v", cs.name, err)
+		}
+		if d := Distance3(x, y, z, 0, 1, -2); d > 0.0001 {
+			t.Errorf("%s: round trip: expected=0,1,-2 got=%v,%v,%v", cs.name, x, y, z)
+		}
+	}
+}
+
+func TestPickRayClip(t *testing.T) {
+	for _, cs := range testClipSpaces {
+		var P Matrix4
+		SetPerspectiveMatrixClip(&P, cs.clip, math.Pi/2, 1, 1, 10)
+		nearX, nearY, nearZ, farX, farY, farZ, err := PickRayClip(&P, cs.clip, 0, 100, 0, 100, 50, 0)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", cs.name, err)
+		}
+		if d := Distance3(nearX, nearY, nearZ, 0, 1, -1); d > 0.0001 {
+			t.Errorf("%s: near: expected=0,1,-1 got=%v,%v,%v", cs.name, nearX, nearY, nearZ)
+		}
+		if d := Distance3(farX, farY, farZ, 0, 10, -10); d > 0.001 {
+			t.Errorf("%s: far: expected=0,10,-10 got=%v,%v,%v", cs.name, farX, farY, farZ)
+		}
+	}
+}
+
+func TestPickRayClipInfinite(t *testing.T) {
+	for _, cs := range testClipSpaces {
+		var P, V Matrix4
+		SetPerspectiveMatrixInfiniteClip(&P, cs.clip, math.Pi/2, 1, 1)
+		SetViewMatrix(&V, 1, 2, 3, 0, 1, 0, 4, -5, 6)
+		P.Multiply(&V)
+		nearX, nearY, nearZ, farX, farY, farZ, err := PickRayClip(&P, cs.clip, 0, 100, 0, 100, 50, 50)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", cs.name, err)
+		}
+		eye := Vector3{4, -5, 6}
+		dir := Vector3{1, 2, 3}.Sub(eye).Normalize()
+		near := Vector3{nearX, nearY, nearZ}.Sub(eye)
+		far := Vector3{farX, farY, farZ}.Sub(eye)
+		if math.IsNaN(far.Length()) || math.IsInf(far.Length(), 0) || far.Length() <= near.Length() {
+			t.Errorf("%s: far: expected finite point beyond near, got=%v,%v,%v", cs.name, farX, farY, farZ)
+		}
+		if !vector3Close(far.Normalize(), dir) {
+			t.Errorf("%s: far: expected point along %v, got=%v", cs.name, dir, far.Normalize())
+		}
+	}
+}
+
+func TestFrustumClip(t *testing.T) {
+	for _, cs := range testClipSpaces {
+		var P Matrix4
+		SetPerspectiveMatrixClip(&P, cs.clip, math.Pi/2, 1, 1, 10)
+		f := NewFrustumClip(&P, cs.clip)
+		corners, err := f.Corners()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", cs.name, err)
+		}
+		if want := (Vector3{-1, -1, -1}); corners[0].Distance(want) > 0.0001 {
+			t.Errorf("%s: near-bottom-left: expected=%v got=%v", cs.name, want, corners[0])
+		}
+		if want := (Vector3{10, 10, -10}); corners[7].Distance(want) > 0.001 {
+			t.Errorf("%s: far-top-right: expected=%v got=%v", cs.name, want, corners[7])
+		}
+	}
+}
+
+func TestViewportTransformClip(t *testing.T) {
+	x, y, depth := ViewportTransformClip(ClipSpaceWebGPU, 0, 100, 0, 100, 0, 1, 0, 0, 0)
+	if x != 50 || y != 50 || depth != 0 {
+		t.Errorf("expected=50,50,0 got=%v,%v,%v", x, y, depth)
+	}
+}
+
+func TestPerspectiveMatrixReverseZ(t *testing.T) {
+	var P Matrix4
+	SetPerspectiveMatrixReverseZ(&P, math.Pi/2, 1, 1, 10)
+	near := P.TransformPoint(Vector3{0, 0, -1})
+	far := P.TransformPoint(Vector3{0, 0, -10})
+	if !closeToZero(near.Z-1) || !closeToZero(far.Z) {
+		t.Errorf("NDC depth: expected near=1 far=0 got near=%v far=%v", near.Z, far.Z)
+	}
+
+	var O Matrix4
+	SetOrthoMatrixClip(&O, ClipSpaceReverseZ, -1, 1, -1, 1, 1, 10)
+	near = O.TransformPoint(Vector3{0, 0, -1})
+	far = O.TransformPoint(Vector3{0, 0, -10})
+	if !closeToZero(near.Z-1) || !closeToZero(far.Z) {
+		t.Errorf("ortho NDC depth: expected near=1 far=0 got near=%v far=%v", near.Z, far.Z)
+	}
+}
+
+func TestPerspectiveMatrixInfinite(t *testing.T) {
+	tests := []struct {
+		name      string
+		clip      ClipSpace
+		nearDepth float64
+		farDepth  float64
+	}{
+		{"opengl", ClipSpaceOpenGL, -1, 1},
+		{"webgpu", ClipSpaceWebGPU, 0, 1},
+		{"reverse-z", ClipSpaceReverseZ, 1, 0},
+	}
+	for _, test := range tests {
+		var P Matrix4
+		SetPerspectiveMatrixInfiniteClip(&P, test.clip, math.Pi/2, 1, 1)
+		near := P.TransformPoint(Vector3{0, 0, -1})
+		if !closeToZero(near.Z - test.nearDepth) {
+			t.Errorf("%s: near: expected=%v got=%v", test.name, test.nearDepth, near.Z)
+		}
+		far := P.TransformPoint(Vector3{0, 0, -1e7})
+		if math.Abs(far.Z-test.farDepth) > 0.00001 {
+			t.Errorf("%s: far: expected=%v got=%v", test.name, test.farDepth, far.Z)
+		}
+	}
+}
+
+func TestPickRayInfiniteReverseZ(t *testing.T) {
+	var P, V Matrix4
+	SetPerspectiveMatrixInfiniteReverseZ(&P, math.Pi/2, 1, 1)
+	SetViewMatrix(&V, 0, 0, -5, 0, 1, 0, 0, 0, 5)
+	P.Multiply(&V)
+
+	r, err := NewPickRayClip(&P, ClipSpaceReverseZ, 0, 100, 0, 100, 50, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !vector3Close(r.Origin, Vector3{0, 0, 4}) {
+		t.Errorf("origin: expected=%v got=%v", Vector3{0, 0, 4}, r.Origin)
+	}
+	if !vector3Close(r.Direction, Vector3{0, 0, -1}) {
+		t.Errorf("direction: expected=%v got=%v", Vector3{0, 0, -1}, r.Direction)
+	}
+
+	f := NewFrustumClip(&P, ClipSpaceReverseZ)
+	if !f.ContainsPoint(Vector3{0, 0, -1e6}) {
+		t.Errorf("distant point reported outside infinite frustum")
+	}
+	if f.ContainsPoint(Vector3{0, 0, 4.5}) {
+		t.Errorf("point before near plane reported inside")
+	}
+}
@@ -0,0 +1,160 @@
+package goglmath
+
+import (
+	"errors"
+	"math"
+)
+
+// Decomposition holds the components of a matrix as extracted by Decompose.
+//
+// The matrix is rebuilt by Compose as: P*T*R*H*S
+// P = Perspective (identity with last row replaced by the Perspective vector)
+// T = Translation
+// R = Rotation
+// H = Shear (upper triangular)
+// S = Scale
+type Decomposition struct {
+	Translation Vector3
+	Rotation    Quaternion
+	Scale       Vector3 // per-axis scale, negative for mirroring matrices
+	Shear       Vector3 // X = XY shear, Y = XZ shear, Z = YZ shear
+	Perspective Vector4 // last row of P, 0 0 0 1 for affine matrices
+}
+
+// Forward returns the forward vector of the rotation, as expected by SetModelMatrix.
+//
+// null rotation:
+// forward = 0 0 -1 // looking towards -Z
+func (d *Decomposition) Forward() Vector3 {
+	return d.Rotation.Rotate(Vector3{0, 0, -1})
+}
+
+// Up returns the up vector of the rotation, as expected by SetModelMatrix.
+//
+// null rotation:
+// up = 0 1 0 // up direction is +Y
+func (d *Decomposition) Up() Vector3 {
+	return d.Rotation.Rotate(Vector3{0, 1, 0})
+}
+
+// Decompose extracts translation, rotation, scale, shear and perspective from the matrix.
+// Matrices with singular upper 3x3 can't be decomposed.
+// Singularity is tested relative to the length of the longest upper 3x3 column,
+// hence uniformly scaled matrices are accepted at any scale.
+func Decompose(m *Matrix4) (Decomposition, error) {
+	var d Decomposition
+
+	var e [16]float64
+	for i, v := range m.data {
+		e[i] = float64(v)
+	}
+
+	c0 := Vector3{e[0], e[1], e[2]}
+	c1 := Vector3{e[4], e[5], e[6]}
+	c2 := Vector3{e[8], e[9], e[10]}
+	d.Translation = Vector3{e[12], e[13], e[14]}
+
+	// magnitude of the upper 3x3, for relative singularity tests
+	size := math.Max(c0.Length(), math.Max(c1.Length(), c2.Length()))
+	if !(size > 0) {
+		return d, errors.New("decompose: singular matrix")
+	}
+
+	// perspective: M = P*A, A = affine part of M
+	// last row of M = p*A => p = (last row of M) * inverse(A)
+	if e[3] != 0 || e[7] != 0 || e[11] != 0 || e[15] != 1 {
+		row := Vector3{e[3], e[7], e[11]}
+
+		// solve p.c0 = row.X, p.c1 = row.Y, p.c2 = row.Z using the dual basis of the columns
+		det := c0.Dot(c1.Cross(c2))
+		if closeToZero(det / (size * size * size)) {
+			return d, errors.New("decompose: singular matrix")
+		}
+		p := c1.Cross(c2).Scale(row.X).Add(c2.Cross(c0).Scale(row.Y)).Add(c0.Cross(c1).Scale(row.Z)).Scale(1 / det)
+		d.Perspective = Vector4{p.X, p.Y, p.Z, e[15] - p.Dot(d.Translation)}
+	} else {
+		d.Perspective = Vector4{0, 0, 0, 1}
+	}
+
+	// Gram-Schmidt orthogonalization of the upper 3x3 columns
+
+	sx := c0.Length()
+	if closeToZero(sx / size) {
+		return d, errors.New("decompose: singular matrix")
+	}
+	c0 = c0.Scale(1 / sx)
+
+	shearXY := c0.Dot(c1)
+	c1 = c1.Sub(c0.Scale(shearXY))
+	sy := c1.Length()
+	if closeToZero(sy / size) {
+		return d, errors.New("decompose: singular matrix")
+	}
+	c1 = c1.Scale(1 / sy)
+	shearXY /= sy
+
+	shearXZ := c0.Dot(c2)
+	c2 = c2.Sub(c0.Scale(shearXZ))
+	shearYZ := c1.Dot(c2)
+	c2 = c2.Sub(c1.Scale(shearYZ))
+	sz := c2.Length()
+	if closeToZero(sz / size) {
+		return d, errors.New("decompose: singular matrix")
+	}
+	c2 = c2.Scale(1 / sz)
+	shearXZ /= sz
+	shearYZ /= sz
+
+	// mirroring: flip all axes to keep a proper rotation
+	if c0.Dot(c1.Cross(c2)) < 0 {
+		sx, sy, sz = -sx, -sy, -sz
+		c0, c1, c2 = c0.Negate(), c1.Negate(), c2.Negate()
+	}
+
+	d.Scale = Vector3{sx, sy, sz}
+	d.Shear = Vector3{shearXY, shearXZ, shearYZ}
+	d.Rotation = quaternionFromRotation(
+		c0.X, c1.X, c2.X,
+		c0.Y, c1.Y, c2.Y,
+		c0.Z, c1.Z, c2.Z,
+	)
+
+	return d, nil
+}
+
+// Compose builds the matrix from its components, reverting Decompose.
+func Compose(m *Matrix4, d Decomposition) {
+	r0 := d.Rotation.Rotate(Vector3{1, 0, 0})
+	r1 := d.Rotation.Rotate(Vector3{0, 1, 0})
+	r2 := d.Rotation.Rotate(Vector3{0, 0, 1})
+
+	// R*H*S
+	c0 := r0.Scale(d.Scale.X)
+	c1 := r1.Add(r0.Scale(d.Shear.X)).Scale(d.Scale.Y)
+	c2 := r2.Add(r0.Scale(d.Shear.Y)).Add(r1.Scale(d.Shear.Z)).Scale(d.Scale.Z)
+	t := d.Translation
+
+	// last row = p*A
+	p := d.Perspective.Vector3()
+	row0 := p.Dot(c0)
+	row1 := p.Dot(c1)
+	row2 := p.Dot(c2)
+	row3 := p.Dot(t) + d.Perspective.W
+
+	m.data[0] = float32(c0.X)
+	m.data[1] = float32(c0.Y)
+	m.data[2] = float32(c0.Z)
+	m.data[3] = float32(row0)
+	m.data[4] = float32(c1.X)
+	m.data[5] = float32(c1.Y)
+	m.data[6] = float32(c1.Z)
+	m.data[7] = float32(row1)
+	m.data[8] = float32(c2.X)
+	m.data[9] = float32(c2.Y)
+	m.data[10] = float32(c2.Z)
+	m.data[11] = float32(row2)
+	m.data[12] = float32(t.X)
+	m.data[13] = float32(t.Y)
+	m.data[14] = float32(t.Z)
+	m.data[15] = float32(row3)
+}
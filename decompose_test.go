@@ -0,0 +1,96 @@
+package goglmath
+
+import (
+	"testing"
+)
+
+func TestDecomposeModelMatrix(t *testing.T) {
+	var m Matrix4
+	SetModelMatrix(&m, 0, 0, 1, 0, 1, 0, 1, 2, 3) // looking towards +Z, at 1,2,3
+	m.Scale(2, 3, 4, 1)
+
+	d, err := Decompose(&m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (Vector3{1, 2, 3}); !vector3Close(d.Translation, want) {
+		t.Errorf("translation: expected=%v got=%v", want, d.Translation)
+	}
+	if want := (Vector3{2, 3, 4}); !vector3Close(d.Scale, want) {
+		t.Errorf("scale: expected=%v got=%v", want, d.Scale)
+	}
+	if want := (Vector3{0, 0, 1}); !vector3Close(d.Forward(), want) {
+		t.Errorf("forward: expected=%v got=%v", want, d.Forward())
+	}
+	if want := (Vector3{0, 1, 0}); !vector3Close(d.Up(), want) {
+		t.Errorf("up: expected=%v got=%v", want, d.Up())
+	}
+	if d.Shear != (Vector3{}) {
+		t.Errorf("shear: expected=%v got=%v", Vector3{}, d.Shear)
+	}
+}
+
+func TestDecomposeCompose(t *testing.T) {
+	src := Decomposition{
+		Translation: Vector3{-4, 5, 6},
+		Rotation:    NewQuaternionEuler(.3, 1.1, -.7),
+		Scale:       Vector3{-1.5, 2, .5},
+		Shear:       Vector3{.2, -.1, .3},
+		Perspective: Vector4{.01, .02, -.03, 1},
+	}
+	var m Matrix4
+	Compose(&m, src)
+
+	d, err := Decompose(&m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var rebuilt Matrix4
+	Compose(&rebuilt, d)
+	if !matrix4Close(&m, &rebuilt, 0.00001) {
+		t.Errorf("mismatch: original=%v rebuilt=%v", m, rebuilt)
+	}
+	if !closeToZero(d.Perspective.Sub(src.Perspective).Length()) {
+		t.Errorf("perspective: expected=%v got=%v", src.Perspective, d.Perspective)
+	}
+}
+
+func TestDecomposePerspective(t *testing.T) {
+	var m Matrix4
+	SetPerspectiveMatrix(&m, 1, 1.5, 1, 10)
+	d, err := Decompose(&m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var rebuilt Matrix4
+	Compose(&rebuilt, d)
+	if !matrix4Close(&m, &rebuilt, 0.00001) {
+		t.Errorf("mismatch: original=%v rebuilt=%v", m, rebuilt)
+	}
+}
+
+func TestDecomposeSingular(t *testing.T) {
+	m := NewMatrix4Identity()
+	m.Scale(1, 0, 1, 1)
+	if _, err := Decompose(&m); err == nil {
+		t.Errorf("singular matrix decomposed without error")
+	}
+}
+
+func TestDecomposeRelativeThreshold(t *testing.T) {
+	small := NewMatrix4Identity()
+	small.Scale(.001, .001, .001, 1) // det = 1e-9
+	d, err := Decompose(&small)
+	if err != nil {
+		t.Fatalf("small matrix: unexpected error: %v", err)
+	}
+	if want := (Vector3{.001, .001, .001}); !vector3Close(d.Scale, want) {
+		t.Errorf("small matrix: scale: expected=%v got=%v", want, d.Scale)
+	}
+
+	flat := NewMatrix4Identity()
+	flat.Scale(1000, 1000, .0001, 1) // sz/sx = 1e-7
+	if _, err := Decompose(&flat); err == nil {
+		t.Errorf("nearly singular matrix decomposed without error")
+	}
+}
@@ -0,0 +1,184 @@
+package goglmath
+
+import (
+	"fmt"
+)
+
+// Matrix4 stores elements in column-major order:
+// element at (row, col) is Data()[col*4+row]
+//
+// Row-major data, as used by DirectX-style tools, is the transpose of the column-major data.
+// Row-major matrices built for row vectors (v*M) have the same memory layout as column-major matrices built for column vectors (M*v);
+// such data should be loaded with NewMatrix4FromColumnMajor.
+
+// checkIndex panics if a row or column index is outside 0..3.
+// Without it, an out of range row would silently address a neighbouring column.
+func checkIndex(kind string, i int) {
+	if uint(i) > 3 {
+		panic(fmt.Sprintf("goglmath: %s index %d out of range [0,3]", kind, i))
+	}
+}
+
+// At returns the element at row and col.
+// It panics if row or col is outside 0..3.
+func (m *Matrix4) At(row, col int) float64 {
+	checkIndex("row", row)
+	checkIndex("column", col)
+	return float64(m.data[col*4+row])
+}
+
+// Set sets the element at row and col.
+// It panics if row or col is outside 0..3.
+func (m *Matrix4) Set(row, col int, v float64) {
+	checkIndex("row", row)
+	checkIndex("column", col)
+	m.data[col*4+row] = float32(v)
+}
+
+// Row returns the row i.
+// It panics if i is outside 0..3.
+func (m *Matrix4) Row(i int) Vector4 {
+	checkIndex("row", i)
+	return Vector4{float64(m.data[i]), float64(m.data[4+i]), float64(m.data[8+i]), float64(m.data[12+i])}
+}
+
+// SetRow sets the row i.
+// It panics if i is outside 0..3.
+func (m *Matrix4) SetRow(i int, v Vector4) {
+	checkIndex("row", i)
+	m.data[i] = float32(v.X)
+	m.data[4+i] = float32(v.Y)
+	m.data[8+i] = float32(v.Z)
+	m.data[12+i] = float32(v.W)
+}
+
+// Column returns the column j.
+// It panics if j is outside 0..3.
+func (m *Matrix4) Column(j int) Vector4 {
+	checkIndex("column", j)
+	c := m.data[j*4 : j*4+4]
+	return Vector4{float64(c[0]), float64(c[1]), float64(c[2]), float64(c[3])}
+}
+
+// SetColumn sets the column j.
+// It panics if j is outside 0..3.
+func (m *Matrix4) SetColumn(j int, v Vector4) {
+	checkIndex("column", j)
+	c := m.data[j*4 : j*4+4]
+	c[0] = float32(v.X)
+	c[1] = float32(v.Y)
+	c[2] = float32(v.Z)
+	c[3] = float32(v.W)
+}
+
+// NewMatrix4FromArray creates a matrix from column-major elements.
+func NewMatrix4FromArray(a [16]float32) Matrix4 {
+	return Matrix4{a}
+}
+
+// NewMatrix4FromArray64 creates a matrix from column-major elements.
+func NewMatrix4FromArray64(a [16]float64) Matrix4 {
+	var m Matrix4
+	for i, v := range a {
+		m.data[i] = float32(v)
+	}
+	return m
+}
+
+// NewMatrix4FromRows creates a matrix from rows: rows[row][col]
+func NewMatrix4FromRows(rows [4][4]float64) Matrix4 {
+	var m Matrix4
+	for r, row := range rows {
+		for c, v := range row {
+			m.data[c*4+r] = float32(v)
+		}
+	}
+	return m
+}
+
+// NewMatrix4FromColumnMajor creates a matrix from a slice of 16 column-major elements.
+func NewMatrix4FromColumnMajor(s []float32) (Matrix4, error) {
+	var m Matrix4
+	if len(s) != 16 {
+		return m, fmt.Errorf("newMatrix4FromColumnMajor: expected 16 elements, found %d", len(s))
+	}
+	copy(m.data[:], s)
+	return m, nil
+}
+
+// NewMatrix4FromRowMajor creates a matrix from a slice of 16 row-major elements.
+func NewMatrix4FromRowMajor(s []float32) (Matrix4, error) {
+	var m Matrix4
+	if len(s) != 16 {
+		return m, fmt.Errorf("newMatrix4FromRowMajor: expected 16 elements, found %d", len(s))
+	}
+	for i, v := range s {
+		m.data[(i%4)*4+i/4] = v
+	}
+	return m, nil
+}
+
+// NewMatrix4FromColumnMajor64 creates a matrix from a slice of 16 column-major elements.
+func NewMatrix4FromColumnMajor64(s []float64) (Matrix4, error) {
+	var m Matrix4
+	if len(s) != 16 {
+		return m, fmt.Errorf("newMatrix4FromColumnMajor64: expected 16 elements, found %d", len(s))
+	}
+	for i, v := range s {
+		m.data[i] = float32(v)
+	}
+	return m, nil
+}
+
+// NewMatrix4FromRowMajor64 creates a matrix from a slice of 16 row-major elements.
+func NewMatrix4FromRowMajor64(s []float64) (Matrix4, error) {
+	var m Matrix4
+	if len(s) != 16 {
+		return m, fmt.Errorf("newMatrix4FromRowMajor64: expected 16 elements, found %d", len(s))
+	}
+	for i, v := range s {
+		m.data[(i%4)*4+i/4] = float32(v)
+	}
+	return m, nil
+}
+
+// Array returns the column-major elements.
+func (m *Matrix4) Array() [16]float32 {
+	return m.data
+}
+
+// Array64 returns the column-major elements.
+func (m *Matrix4) Array64() [16]float64 {
+	var a [16]float64
+	for i, v := range m.data {
+		a[i] = float64(v)
+	}
+	return a
+}
+
+// Rows returns the elements as rows: rows[row][col]
+func (m *Matrix4) Rows() [4][4]float64 {
+	var rows [4][4]float64
+	for i, v := range m.data {
+		rows[i%4][i/4] = float64(v)
+	}
+	return rows
+}
+
+// RowMajor returns the row-major elements.
+func (m *Matrix4) RowMajor() [16]float32 {
+	var a [16]float32
+	for i, v := range m.data {
+		a[(i%4)*4+i/4] = v
+	}
+	return a
+}
+
+// RowMajor64 returns the row-major elements.
+func (m *Matrix4) RowMajor64() [16]float64 {
+	var a [16]float64
+	for i, v := range m.data {
+		a[(i%4)*4+i/4] = float64(v)
+	}
+	return a
+}
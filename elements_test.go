@@ -0,0 +1,111 @@
+package goglmath
+
+import (
+	"testing"
+)
+
+func TestMatrix4Elements(t *testing.T) {
+	m := NewMatrix4Identity()
+	m.Translate(5, 6, 7, 1)
+	if got := m.At(1, 3); got != 6 {
+		t.Errorf("at: expected=6 got=%v", got)
+	}
+	m.Set(2, 0, 9)
+	if got := m.Data()[2]; got != 9 {
+		t.Errorf("set: expected data[2]=9 got=%v", got)
+	}
+	if got, want := m.Row(2), (Vector4{9, 0, 1, 7}); got != want {
+		t.Errorf("row: expected=%v got=%v", want, got)
+	}
+	if got, want := m.Column(3), (Vector4{5, 6, 7, 1}); got != want {
+		t.Errorf("column: expected=%v got=%v", want, got)
+	}
+	m.SetRow(3, Vector4{1, 2, 3, 4})
+	m.SetColumn(0, Vector4{-1, -2, -3, -4})
+	if got, want := m.Row(3), (Vector4{-4, 2, 3, 4}); got != want {
+		t.Errorf("set row/column: expected=%v got=%v", want, got)
+	}
+}
+
+func TestMatrix4ElementsOutOfRange(t *testing.T) {
+	m := NewMatrix4Identity()
+	tests := []struct {
+		name string
+		call func()
+		want string
+	}{
+		{"at row", func() { m.At(4, 0) }, "goglmath: row index 4 out of range [0,3]"},
+		{"at column", func() { m.At(0, -1) }, "goglmath: column index -1 out of range [0,3]"},
+		{"set row", func() { m.Set(-1, 0, 1) }, "goglmath: row index -1 out of range [0,3]"},
+		{"set column", func() { m.Set(0, 4, 1) }, "goglmath: column index 4 out of range [0,3]"},
+		{"row", func() { m.Row(4) }, "goglmath: row index 4 out of range [0,3]"},
+		{"set row vector", func() { m.SetRow(-1, Vector4{}) }, "goglmath: row index -1 out of range [0,3]"},
+		{"column", func() { m.Column(5) }, "goglmath: column index 5 out of range [0,3]"},
+		{"set column vector", func() { m.SetColumn(4, Vector4{}) }, "goglmath: column index 4 out of range [0,3]"},
+	}
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if got := recover(); got != test.want {
+					t.Errorf("%s: expected panic %q got %v", test.name, test.want, got)
+				}
+			}()
+			test.call()
+		}()
+	}
+	if want := NewMatrix4Identity(); !Matrix4Equal(&m, &want) {
+		t.Errorf("matrix changed: %v", m)
+	}
+}
+
+func TestMatrix4ImportExport(t *testing.T) {
+	rowMajor := []float32{
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+		13, 14, 15, 16,
+	}
+	rowMajor64 := make([]float64, 16)
+	for i, v := range rowMajor {
+		rowMajor64[i] = float64(v)
+	}
+	rows := [4][4]float64{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11, 12}, {13, 14, 15, 16}}
+	want := NewMatrix4FromRows(rows)
+	if got := want.At(1, 2); got != 7 {
+		t.Fatalf("from rows: expected at(1,2)=7 got=%v", got)
+	}
+
+	columnMajor := want.Array()
+	m1, err1 := NewMatrix4FromRowMajor(rowMajor)
+	m2, err2 := NewMatrix4FromRowMajor64(rowMajor64)
+	m3, err3 := NewMatrix4FromColumnMajor(columnMajor[:])
+	a64 := want.Array64()
+	m4, err4 := NewMatrix4FromColumnMajor64(a64[:])
+	for i, err := range []error{err1, err2, err3, err4} {
+		if err != nil {
+			t.Fatalf("import %d: %v", i, err)
+		}
+	}
+	for i, m := range []Matrix4{m1, m2, m3, m4, NewMatrix4FromArray(columnMajor), NewMatrix4FromArray64(a64)} {
+		if !Matrix4Equal(&m, &want) {
+			t.Errorf("import %d: expected=%v got=%v", i, want, m)
+		}
+	}
+
+	if got := want.Rows(); got != rows {
+		t.Errorf("rows: expected=%v got=%v", rows, got)
+	}
+	if got := want.RowMajor(); got != [16]float32(rowMajor) {
+		t.Errorf("row-major: expected=%v got=%v", rowMajor, got)
+	}
+	if got := want.RowMajor64(); got != [16]float64(rowMajor64) {
+		t.Errorf("row-major 64: expected=%v got=%v", rowMajor64, got)
+	}
+
+	if _, err := NewMatrix4FromRowMajor(rowMajor[:15]); err == nil {
+		t.Errorf("expected error for short slice")
+	}
+	if _, err := NewMatrix4FromColumnMajor64(append(rowMajor64, 0)); err == nil {
+		t.Errorf("expected error for long slice")
+	}
+}
@@ -0,0 +1,192 @@
+package goglmath
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String formats the matrix in row-major order, rows separated by semicolon:
+// [1 0 0 5; 0 1 0 6; 0 0 1 7; 0 0 0 1]
+// Notice the internal storage (Data) is column-major.
+func (m Matrix4) String() string {
+	return fmt.Sprint(m)
+}
+
+// Format implements fmt.Formatter.
+//
+// Verbs v s g G e E f F format every element, precision applies to each element.
+// v and s use the shortest representation (as g).
+// Flags + or # print one row per line, with aligned columns:
+//
+//	[1 0 0 5
+//	 0 1 0 6
+//	 0 0 1 7
+//	 0 0 0 1]
+//
+// Width sets the minimum width of each element.
+func (m Matrix4) Format(f fmt.State, verb rune) {
+	var format byte
+	switch verb {
+	case 'v', 's':
+		format = 'g'
+	case 'g', 'G', 'e', 'E', 'f', 'F':
+		format = byte(verb)
+	default:
+		fmt.Fprintf(f, "%%!%c(goglmath.Matrix4=%s)", verb, m.String())
+		return
+	}
+	if format == 'F' {
+		format = 'f'
+	}
+
+	prec, hasPrec := f.Precision()
+	if !hasPrec {
+		prec = -1
+	}
+	width, _ := f.Width()
+
+	var cells [16]string
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			s := strconv.FormatFloat(float64(m.data[c*4+r]), format, prec, 32)
+			if len(s) > width {
+				width = len(s)
+			}
+			cells[r*4+c] = s
+		}
+	}
+
+	multiLine := f.Flag('+') || f.Flag('#')
+	if !multiLine {
+		if _, hasWidth := f.Width(); !hasWidth {
+			width = 0 // compact: no alignment
+		}
+	}
+
+	var b strings.Builder
+	b.WriteByte('[')
+	for r := 0; r < 4; r++ {
+		if r > 0 {
+			if multiLine {
+				b.WriteString("\n ")
+			} else {
+				b.WriteString("; ")
+			}
+		}
+		for c := 0; c < 4; c++ {
+			if c > 0 {
+				b.WriteByte(' ')
+			}
+			s := cells[r*4+c]
+			for i := len(s); i < width; i++ {
+				b.WriteByte(' ')
+			}
+			b.WriteString(s)
+		}
+	}
+	b.WriteByte(']')
+	f.Write([]byte(b.String()))
+}
+
+// ParseMatrix4 parses the text produced by Matrix4 String and Format.
+//
+// Elements are listed in row-major order, separated by spaces, commas, semicolons or newlines.
+// Surrounding brackets are optional.
+//
+// GLSL mat4 constructors are also accepted, with elements in column-major order as in GLSL:
+// mat4(1.0, 0.0, ... 16 scalars)
+// mat4(vec4(...), vec4(...), vec4(...), vec4(...)) // columns
+// Anything else, such as a wrong number of elements or trailing text, is an error.
+func ParseMatrix4(s string) (Matrix4, error) {
+	var m Matrix4
+
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "mat4") {
+		return parseGLSLMatrix4(s)
+	}
+
+	if strings.HasPrefix(s, "[") != strings.HasSuffix(s, "]") {
+		return m, errors.New("parseMatrix4: unbalanced brackets")
+	}
+	s = strings.TrimPrefix(s, "[")
+	s = strings.TrimSuffix(s, "]")
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == ',' || r == ';'
+	})
+	if len(fields) != 16 {
+		return m, fmt.Errorf("parseMatrix4: expected 16 elements, found %d", len(fields))
+	}
+	for i, field := range fields {
+		v, err := strconv.ParseFloat(field, 32)
+		if err != nil {
+			return m, fmt.Errorf("parseMatrix4: %v", err)
+		}
+		r, c := i/4, i%4
+		m.data[c*4+r] = float32(v)
+	}
+	return m, nil
+}
+
+func parseGLSLMatrix4(s string) (Matrix4, error) {
+	var m Matrix4
+
+	s = strings.TrimSpace(strings.TrimPrefix(s, "mat4"))
+	if !strings.HasPrefix(s, "(") || !strings.HasSuffix(s, ")") {
+		return m, errors.New("parseMatrix4: malformed mat4 constructor")
+	}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+
+	if !strings.HasPrefix(s, "vec4") {
+		if err := parseGLSLScalars(m.data[:], s); err != nil {
+			return m, err
+		}
+		return m, nil
+	}
+
+	for col := 0; col < 4; col++ {
+		if col > 0 {
+			if !strings.HasPrefix(s, ",") {
+				return m, errors.New("parseMatrix4: mat4 expects 4 vec4 columns")
+			}
+			s = strings.TrimSpace(s[1:])
+		}
+		if !strings.HasPrefix(s, "vec4") {
+			return m, errors.New("parseMatrix4: mat4 expects 4 vec4 columns")
+		}
+		s = strings.TrimSpace(strings.TrimPrefix(s, "vec4"))
+		end := strings.IndexByte(s, ')')
+		if !strings.HasPrefix(s, "(") || end < 0 {
+			return m, errors.New("parseMatrix4: malformed vec4 constructor")
+		}
+		if err := parseGLSLScalars(m.data[col*4:col*4+4], s[1:end]); err != nil {
+			return m, err
+		}
+		s = strings.TrimSpace(s[end+1:])
+	}
+	if s != "" {
+		return m, fmt.Errorf("parseMatrix4: unexpected text after columns: %q", s)
+	}
+	return m, nil
+}
+
+// parseGLSLScalars parses exactly len(dst) comma-separated GLSL float literals.
+func parseGLSLScalars(dst []float32, s string) error {
+	args := strings.Split(s, ",")
+	if len(args) != len(dst) {
+		return fmt.Errorf("parseMatrix4: expected %d scalars, found %d", len(dst), len(args))
+	}
+	for i, arg := range args {
+		arg = strings.TrimSpace(arg)
+		if n := len(arg); n > 1 && (arg[n-1] == 'f' || arg[n-1] == 'F') && (arg[n-2] == '.' || (arg[n-2] >= '0' && arg[n-2] <= '9')) {
+			arg = arg[:n-1] // float suffix: 1.0f
+		}
+		v, err := strconv.ParseFloat(arg, 32)
+		if err != nil {
+			return fmt.Errorf("parseMatrix4: %v", err)
+		}
+		dst[i] = float32(v)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package goglmath
+
+import (
+	"fmt"
+	"testing"
+)
+
+func testFormatMatrix() Matrix4 {
+	m := NewMatrix4Identity()
+	m.Translate(5, -6.5, 0.1, 1)
+	return m
+}
+
+func TestMatrix4Format(t *testing.T) {
+	m := testFormatMatrix()
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%v", "[1 0 0 5; 0 1 0 -6.5; 0 0 1 0.1; 0 0 0 1]"},
+		{"%.2f", "[1.00 0.00 0.00 5.00; 0.00 1.00 0.00 -6.50; 0.00 0.00 1.00 0.10; 0.00 0.00 0.00 1.00]"},
+		{"%+v", "[   1    0    0    5\n    0    1    0 -6.5\n    0    0    1  0.1\n    0    0    0    1]"},
+		{"%x", "%!x(goglmath.Matrix4=[1 0 0 5; 0 1 0 -6.5; 0 0 1 0.1; 0 0 0 1])"},
+	}
+	for _, test := range tests {
+		if got := fmt.Sprintf(test.format, m); got != test.want {
+			t.Errorf("%s: expected=%q got=%q", test.format, test.want, got)
+		}
+	}
+	if got := m.String(); got != tests[0].want {
+		t.Errorf("String: expected=%q got=%q", tests[0].want, got)
+	}
+	if got := fmt.Sprintf("%v", &m); got != tests[0].want {
+		t.Errorf("pointer: expected=%q got=%q", tests[0].want, got)
+	}
+}
+
+func TestParseMatrix4(t *testing.T) {
+	m := testFormatMatrix()
+	for _, format := range []string{"%v", "%+v", "%#g", "%.9e"} {
+		text := fmt.Sprintf(format, m)
+		got, err := ParseMatrix4(text)
+		if err != nil {
+			t.Errorf("%s: parse %q: %v", format, text, err)
+			continue
+		}
+		if !Matrix4Equal(&got, &m) {
+			t.Errorf("%s: round trip: expected=%v got=%v", format, m, got)
+		}
+	}
+
+	if _, err := ParseMatrix4("[1 2 3]"); err == nil {
+		t.Errorf("expected error for short matrix")
+	}
+	if _, err := ParseMatrix4("1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 x"); err == nil {
+		t.Errorf("expected error for bad element")
+	}
+	if _, err := ParseMatrix4("1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17"); err == nil {
+		t.Errorf("expected error for long matrix")
+	}
+	if _, err := ParseMatrix4("[1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16"); err == nil {
+		t.Errorf("expected error for unbalanced brackets")
+	}
+}
+
+func TestParseMatrix4GLSL(t *testing.T) {
+	m := testFormatMatrix()
+	inputs := []string{
+		"mat4(1.0, 0.0, 0.0, 0.0, 0.0, 1.0, 0.0, 0.0, 0.0, 0.0, 1.0, 0.0, 5.0, -6.5, 0.1, 1.0)",
+		"mat4(vec4(1, 0, 0, 0), vec4(0, 1, 0, 0), vec4(0, 0, 1, 0), vec4(5.0f, -6.5, .1, 1))",
+	}
+	for _, input := range inputs {
+		got, err := ParseMatrix4(input)
+		if err != nil {
+			t.Errorf("parse %q: %v", input, err)
+			continue
+		}
+		if !Matrix4Equal(&got, &m) {
+			t.Errorf("parse %q: expected=%v got=%v", input, m, got)
+		}
+	}
+
+	for _, input := range []string{
+		"mat4(2.0)",
+		"mat4(1, 2)",
+		"mat4(1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0)",
+		"mat4(1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1) x",
+		"mat4(1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1))",
+		"mat4(1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, (1))",
+		"mat4 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1",
+		"mat4(vec4(1, 0, 0, 0), vec4(0, 1, 0, 0), vec4(0, 0, 1, 0))",
+		"mat4(vec4(1, 0, 0), vec4(0, 1, 0, 0), vec4(0, 0, 1, 0), vec4(0, 0, 0, 1, 0))",
+		"mat4(vec4(1, 0, 0, 0), vec4(0, 1, 0, 0), vec4(0, 0, 1, 0), vec4(0, 0, 0, 1), vec4(0, 0, 0, 1))",
+		"mat4(vec4(1, 0, 0, 0), vec4(0, 1, 0, 0), vec4(0, 0, 1, 0), vec4(0, 0, 0, 1) 2)",
+		"mat4(vec4(1, 0, 0, 0) vec4(0, 1, 0, 0), vec4(0, 0, 1, 0), vec4(0, 0, 0, 1))",
+		"mat4(vec4(1, 0, 0, 0), vec4(0, 1, 0, 0), vec4(0, 0, 1, 0), 0, 0, 0, 1)",
+		"mat4(vec4 1, 0, 0, 0), vec4(0, 1, 0, 0), vec4(0, 0, 1, 0), vec4(0, 0, 0, 1))",
+	} {
+		if got, err := ParseMatrix4(input); err == nil {
+			t.Errorf("parse %q: expected error, got=%v", input, got)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package goglmath
+
+import (
+	"errors"
+)
+
+// Frustum plane indices.
+const (
+	FrustumLeft = iota
+	FrustumRight
+	FrustumBottom
+	FrustumTop
+	FrustumNear
+	FrustumFar
+)
+
+// Containment is the result of a frustum containment test.
+type Containment int
+
+// Containment results.
+const (
+	Outside      Containment = iota // completely outside the frustum
+	Intersecting                    // partially inside the frustum
+	Inside                          // completely inside the frustum
+)
+
+// Frustum is the view volume defined by six clip planes.
+// Plane normals point inwards.
+type Frustum struct {
+	Planes [6]Plane // indexed by FrustumLeft, FrustumRight, ...
+}
+
+// NewFrustum extracts the frustum planes from the camera matrix.
+//
+// camera = includes both the perspective and view transforms
+// Either perspective (SetPerspectiveMatrix) or orthographic (SetOrthoMatrix) projections are supported.
+// For projections with far plane at infinity (SetPerspectiveMatrixInfinite), the far plane contains every point and Corners fails.
+// If camera includes only the projection, the planes are in view space.
+// If camera is P*V, the planes are in world space.
+// If camera is P*V*T*R*U*S, the planes are in object space.
+func NewFrustum(camera *Matrix4) Frustum {
+	return NewFrustumClip(camera, ClipSpaceOpenGL)
+}
+
+// NewFrustumClip extracts the frustum planes from the camera matrix built for the given clip space convention.
+// See NewFrustum.
+func NewFrustumClip(camera *Matrix4, clip ClipSpace) Frustum {
+	d := &camera.data
+	row0 := Vector4{float64(d[0]), float64(d[4]), float64(d[8]), float64(d[12])}
+	row1 := Vector4{float64(d[1]), float64(d[5]), float64(d[9]), float64(d[13])}
+	row2 := Vector4{float64(d[2]), float64(d[6]), float64(d[10]), float64(d[14])}
+	row3 := Vector4{float64(d[3]), float64(d[7]), float64(d[11]), float64(d[15])}
+
+	var f Frustum
+	f.Planes[FrustumLeft] = planeFromRow(row3.Add(row0))
+	f.Planes[FrustumRight] = planeFromRow(row3.Sub(row0))
+	f.Planes[FrustumBottom] = planeFromRow(row3.Add(row1))
+	f.Planes[FrustumTop] = planeFromRow(row3.Sub(row1))
+	if clip.FlipY {
+		f.Planes[FrustumBottom], f.Planes[FrustumTop] = f.Planes[FrustumTop], f.Planes[FrustumBottom]
+	}
+	// min depth plane: -w <= z (or 0 <= z)
+	// max depth plane: z <= w
+	var minDepth Plane
+	if clip.ZeroToOneDepth {
+		minDepth = planeFromRow(row2)
+	} else {
+		minDepth = planeFromRow(row3.Add(row2))
+	}
+	maxDepth := planeFromRow(row3.Sub(row2))
+	if clip.ReverseZ {
+		f.Planes[FrustumNear], f.Planes[FrustumFar] = maxDepth, minDepth
+	} else {
+		f.Planes[FrustumNear], f.Planes[FrustumFar] = minDepth, maxDepth
+	}
+	return f
+}
+
+func planeFromRow(r Vector4) Plane {
+	return Plane{Vector3{r.X, r.Y, r.Z}, r.W}.Normalize()
+}
+
+// Corners calculates the eight corner points of the frustum.
+// Corners are ordered as: near-bottom-left, near-bottom-right, near-top-left, near-top-right, far-bottom-left, far-bottom-right, far-top-left, far-top-right.
+func (f *Frustum) Corners() ([8]Vector3, error) {
+	var corners [8]Vector3
+	i := 0
+	for _, depth := range []int{FrustumNear, FrustumFar} {
+		for _, vertical := range []int{FrustumBottom, FrustumTop} {
+			for _, horizontal := range []int{FrustumLeft, FrustumRight} {
+				p, ok := IntersectPlanes(f.Planes[depth], f.Planes[vertical], f.Planes[horizontal])
+				if !ok {
+					return corners, errors.New("corners: frustum planes do not intersect")
+				}
+				corners[i] = p
+				i++
+			}
+		}
+	}
+	return corners, nil
+}
+
+// ContainsPoint reports if the point is inside the frustum.
+func (f *Frustum) ContainsPoint(p Vector3) bool {
+	for _, plane := range f.Planes {
+		if plane.Distance(p) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsSphere tests the sphere against the frustum.
+func (f *Frustum) ContainsSphere(s Sphere) Containment {
+	result := Inside
+	for _, plane := range f.Planes {
+		d := plane.Distance(s.Center)
+		if d < -s.Radius {
+			return Outside
+		}
+		if d < s.Radius {
+			result = Intersecting
+		}
+	}
+	return result
+}
+
+// ContainsAABB tests the axis-aligned box against the frustum.
+func (f *Frustum) ContainsAABB(b AABB) Containment {
+	result := Inside
+	for _, plane := range f.Planes {
+		// p: box vertex farthest along the normal
+		// n: box vertex farthest against the normal
+		p, n := b.Min, b.Max
+		if plane.Normal.X >= 0 {
+			p.X, n.X = b.Max.X, b.Min.X
+		}
+		if plane.Normal.Y >= 0 {
+			p.Y, n.Y = b.Max.Y, b.Min.Y
+		}
+		if plane.Normal.Z >= 0 {
+			p.Z, n.Z = b.Max.Z, b.Min.Z
+		}
+		if plane.Distance(p) < 0 {
+			return Outside
+		}
+		if plane.Distance(n) < 0 {
+			result = Intersecting
+		}
+	}
+	return result
+}
+
+// ContainsOBB tests the oriented box against the frustum.
+func (f *Frustum) ContainsOBB(o OBB) Containment {
+	result := Inside
+	for _, plane := range f.Planes {
+		d := plane.Distance(o.Center)
+		r := o.projectedRadius(plane.Normal)
+		if d < -r {
+			return Outside
+		}
+		if d < r {
+			result = Intersecting
+		}
+	}
+	return result
+}
@@ -0,0 +1,111 @@
+package goglmath
+
+import (
+	"math"
+	"testing"
+)
+
+func testFrustum() Frustum {
+	var p Matrix4
+	SetPerspectiveMatrix(&p, math.Pi/2, 1, 1, 10)
+	return NewFrustum(&p)
+}
+
+func TestFrustumCorners(t *testing.T) {
+	f := testFrustum()
+	corners, err := f.Corners()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := [8]Vector3{
+		{-1, -1, -1}, {1, -1, -1}, {-1, 1, -1}, {1, 1, -1},
+		{-10, -10, -10}, {10, -10, -10}, {-10, 10, -10}, {10, 10, -10},
+	}
+	for i, c := range corners {
+		if c.Distance(want[i]) > 0.0001 {
+			t.Errorf("corner %d: expected=%v got=%v", i, want[i], c)
+		}
+	}
+}
+
+func TestFrustumOrtho(t *testing.T) {
+	var o Matrix4
+	SetOrthoMatrix(&o, -2, 2, -1, 1, 1, 5)
+	f := NewFrustum(&o)
+	if !f.ContainsPoint(Vector3{1.9, .9, -4.9}) {
+		t.Errorf("point inside ortho frustum reported outside")
+	}
+	if f.ContainsPoint(Vector3{0, 0, -.5}) {
+		t.Errorf("point before near plane reported inside")
+	}
+}
+
+func TestFrustumContainsPoint(t *testing.T) {
+	f := testFrustum()
+	if !f.ContainsPoint(Vector3{0, 0, -5}) {
+		t.Errorf("center point reported outside")
+	}
+	if f.ContainsPoint(Vector3{0, 0, 5}) {
+		t.Errorf("point behind camera reported inside")
+	}
+	if f.ContainsPoint(Vector3{0, 0, -11}) {
+		t.Errorf("point beyond far plane reported inside")
+	}
+}
+
+func TestFrustumContainsSphere(t *testing.T) {
+	f := testFrustum()
+	tests := []struct {
+		s    Sphere
+		want Containment
+	}{
+		{Sphere{Vector3{0, 0, -5}, 1}, Inside},
+		{Sphere{Vector3{0, 0, -10}, 1}, Intersecting},
+		{Sphere{Vector3{0, 0, 5}, 1}, Outside},
+	}
+	for _, test := range tests {
+		if got := f.ContainsSphere(test.s); got != test.want {
+			t.Errorf("sphere %v: expected=%v got=%v", test.s, test.want, got)
+		}
+	}
+}
+
+func TestFrustumContainsAABB(t *testing.T) {
+	f := testFrustum()
+	tests := []struct {
+		b    AABB
+		want Containment
+	}{
+		{AABB{Vector3{-1, -1, -6}, Vector3{1, 1, -4}}, Inside},
+		{AABB{Vector3{-1, -1, -6}, Vector3{20, 1, -4}}, Intersecting},
+		{AABB{Vector3{-1, -1, 1}, Vector3{1, 1, 2}}, Outside},
+	}
+	for _, test := range tests {
+		if got := f.ContainsAABB(test.b); got != test.want {
+			t.Errorf("box %v: expected=%v got=%v", test.b, test.want, got)
+		}
+	}
+}
+
+func TestFrustumContainsOBB(t *testing.T) {
+	f := testFrustum()
+	model := NewMatrix4Identity()
+	model.Translate(0, 0, -5, 1)
+	model.RotateQuaternion(NewQuaternionAxisAngle(Vector3{0, 1, 0}, math.Pi/4))
+	box := AABB{Vector3{-1, -1, -1}, Vector3{1, 1, 1}}
+	if got := f.ContainsOBB(NewOBB(&model, box)); got != Inside {
+		t.Errorf("expected=%v got=%v", Inside, got)
+	}
+	model.Translate(0, 0, 20, 1)
+	if got := f.ContainsOBB(NewOBB(&model, box)); got != Outside {
+		t.Errorf("expected=%v got=%v", Outside, got)
+	}
+}
+
+func BenchmarkFrustumContainsAABB(b *testing.B) {
+	f := testFrustum()
+	box := AABB{Vector3{-1, -1, -6}, Vector3{1, 1, -4}}
+	for n := 0; n < b.N; n++ {
+		f.ContainsAABB(box)
+	}
+}
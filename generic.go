@@ -0,0 +1,214 @@
+package goglmath
+
+import (
+	"errors"
+	"math"
+)
+
+// Mat4 is a generic 4x4 matrix with elements stored in column-major order, as Matrix4.
+//
+// Mat4[float32] is GPU-ready via Data.
+// Mat4[float64] keeps full precision for simulation and large-world coordinates:
+// build and combine matrices in float64, then convert the result to float32 for rendering.
+//
+// Matrix4 remains the float32 type used by the rest of the package; see NewMat4 and Mat4.Matrix4 for conversions.
+type Mat4[T Float] struct {
+	data [16]T
+}
+
+// NewMat4Identity creates an identity matrix.
+func NewMat4Identity[T Float]() Mat4[T] {
+	var m Mat4[T]
+	m.SetIdentity()
+	return m
+}
+
+// NewMat4 converts from Matrix4.
+func NewMat4[T Float](m *Matrix4) Mat4[T] {
+	var r Mat4[T]
+	for i, v := range m.data {
+		r.data[i] = T(v)
+	}
+	return r
+}
+
+// ConvertMat4 converts between precisions.
+//
+// m32 := ConvertMat4[float32](&m64)
+func ConvertMat4[D, S Float](m *Mat4[S]) Mat4[D] {
+	var r Mat4[D]
+	for i, v := range m.data {
+		r.data[i] = D(v)
+	}
+	return r
+}
+
+// Matrix4 converts to Matrix4.
+func (m *Mat4[T]) Matrix4() Matrix4 {
+	var r Matrix4
+	for i, v := range m.data {
+		r.data[i] = float32(v)
+	}
+	return r
+}
+
+// Data returns matrix data as slice in column-major order.
+// For Mat4[float32], the slice is ready to GPU upload.
+func (m *Mat4[T]) Data() []T {
+	return m.data[:]
+}
+
+// At returns the element at row and col.
+// It panics if row or col is outside 0..3.
+func (m *Mat4[T]) At(row, col int) T {
+	checkIndex("row", row)
+	checkIndex("column", col)
+	return m.data[col*4+row]
+}
+
+// Set sets the element at row and col.
+// It panics if row or col is outside 0..3.
+func (m *Mat4[T]) Set(row, col int, v T) {
+	checkIndex("row", row)
+	checkIndex("column", col)
+	m.data[col*4+row] = v
+}
+
+// SetIdentity sets the matrix to identity.
+func (m *Mat4[T]) SetIdentity() {
+	m.data = [16]T{
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	}
+}
+
+// Identity checks if the matrix is identity.
+func (m *Mat4[T]) Identity() bool {
+	return *m == NewMat4Identity[T]()
+}
+
+// Multiply multiplies the matrix by another matrix.
+func (m *Mat4[T]) Multiply(n *Mat4[T]) {
+	var r [16]T
+	for c := 0; c < 4; c++ {
+		for row := 0; row < 4; row++ {
+			r[c*4+row] = m.data[row]*n.data[c*4] + m.data[4+row]*n.data[c*4+1] + m.data[8+row]*n.data[c*4+2] + m.data[12+row]*n.data[c*4+3]
+		}
+	}
+	m.data = r
+}
+
+// Translate multiplies the matrix by a translation matrix.
+func (m *Mat4[T]) Translate(t Vec3[T]) {
+	for row := 0; row < 4; row++ {
+		m.data[12+row] += m.data[row]*t.X + m.data[4+row]*t.Y + m.data[8+row]*t.Z
+	}
+}
+
+// Scale multiplies the matrix by a scaling matrix.
+func (m *Mat4[T]) Scale(s Vec3[T]) {
+	for row := 0; row < 4; row++ {
+		m.data[row] *= s.X
+		m.data[4+row] *= s.Y
+		m.data[8+row] *= s.Z
+	}
+}
+
+// Transpose transposes the matrix.
+func (m *Mat4[T]) Transpose() {
+	for c := 0; c < 4; c++ {
+		for r := c + 1; r < 4; r++ {
+			m.data[c*4+r], m.data[r*4+c] = m.data[r*4+c], m.data[c*4+r]
+		}
+	}
+}
+
+// Determinant calculates the determinant of the matrix.
+func (m *Mat4[T]) Determinant() T {
+	var adj [16]T
+	return adjugate(&adj, &m.data)
+}
+
+// Invert inverts the matrix.
+func (m *Mat4[T]) Invert() error {
+	return m.CopyInverseFrom(m)
+}
+
+// CopyInverseFrom sets the matrix as inverse of another source matrix.
+// If src is singular, the matrix receives a copy of src and an error is returned.
+func (m *Mat4[T]) CopyInverseFrom(src *Mat4[T]) error {
+	var adj [16]T
+	det := adjugate(&adj, &src.data)
+	if det == 0 {
+		m.data = src.data
+		return errors.New("copyInverseFrom: null determinant")
+	}
+	invDet := 1 / det
+	for i, v := range adj {
+		m.data[i] = v * invDet
+	}
+	return nil
+}
+
+// Transform multiplies the matrix by vector v.
+func (m *Mat4[T]) Transform(v Vec4[T]) Vec4[T] {
+	d := &m.data
+	return Vec4[T]{
+		d[0]*v.X + d[4]*v.Y + d[8]*v.Z + d[12]*v.W,
+		d[1]*v.X + d[5]*v.Y + d[9]*v.Z + d[13]*v.W,
+		d[2]*v.X + d[6]*v.Y + d[10]*v.Z + d[14]*v.W,
+		d[3]*v.X + d[7]*v.Y + d[11]*v.Z + d[15]*v.W,
+	}
+}
+
+// TransformPoint transforms the point p (w=1), with perspective divide.
+func (m *Mat4[T]) TransformPoint(p Vec3[T]) Vec3[T] {
+	return m.Transform(p.Vec4(1)).PerspectiveDivide()
+}
+
+// TransformDirection transforms the direction d (w=0), ignoring translation.
+func (m *Mat4[T]) TransformDirection(d Vec3[T]) Vec3[T] {
+	return m.Transform(d.Vec4(0)).Vec3()
+}
+
+// SetModelMat4 builds the model matrix, as SetModelMatrix.
+func SetModelMat4[T Float](modelMatrix *Mat4[T], forward, up, translation Vec3[T]) {
+	right := forward.Cross(up).Normalize()
+	modelMatrix.data = [16]T{
+		right.X, right.Y, right.Z, 0,
+		up.X, up.Y, up.Z, 0,
+		-forward.X, -forward.Y, -forward.Z, 0,
+		translation.X, translation.Y, translation.Z, 1,
+	}
+}
+
+// SetViewMat4 builds the view matrix, as SetViewMatrix.
+func SetViewMat4[T Float](viewMatrix *Mat4[T], focus, up, position Vec3[T]) {
+	back := position.Sub(focus).Normalize()
+	right := up.Cross(back).Normalize()
+	newUp := back.Cross(right).Normalize()
+	viewMatrix.data = [16]T{
+		right.X, newUp.X, back.X, 0,
+		right.Y, newUp.Y, back.Y, 0,
+		right.Z, newUp.Z, back.Z, 0,
+		-right.Dot(position), -newUp.Dot(position), -back.Dot(position), 1,
+	}
+}
+
+// SetPerspectiveMat4 builds the perspective projection matrix for the given clip space convention, as SetPerspectiveMatrixClip.
+func SetPerspectiveMat4[T Float](perspectiveMatrix *Mat4[T], clip ClipSpace, fieldOfViewYRadians, aspectRatio, zNear, zFar float64) {
+	f := math.Tan(math.Pi*0.5 - fieldOfViewYRadians*0.5) // = cotan(fieldOfViewYRadians/2)
+	depthScale, depthOffset := perspectiveDepth(clip, zNear, zFar)
+	y := f
+	if clip.FlipY {
+		y = -y
+	}
+	perspectiveMatrix.data = [16]T{
+		T(f / aspectRatio), 0, 0, 0,
+		0, T(y), 0, 0,
+		0, 0, T(depthScale), -1,
+		0, 0, T(depthOffset), 0,
+	}
+}
@@ -0,0 +1,145 @@
+package goglmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMat4MatchesMatrix4(t *testing.T) {
+	list := testMatrices()
+	for i := 1; i < len(list); i++ {
+		a, b := Matrix4{list[i-1]}, Matrix4{list[i]}
+		ga, gb := NewMat4[float32](&a), NewMat4[float32](&b)
+
+		// Mat4 does not round products, so results may differ where the compiler fuses multiply-add.
+		a.Multiply(&b)
+		ga.Multiply(&gb)
+		if got := ga.Matrix4(); !matrix4Close(&got, &a, 0.001) {
+			t.Fatalf("multiply %d: expected=%v got=%v", i, a, got)
+		}
+
+		ga = NewMat4[float32](&a)
+		errA := a.Invert()
+		errG := ga.Invert()
+		if got := ga.Matrix4(); (errA == nil) != (errG == nil) || !Matrix4EqualULP(&got, &a, 4) {
+			t.Fatalf("inverse %d: expected=%v got=%v", i, a, got)
+		}
+	}
+
+	var view Matrix4
+	SetViewMatrix(&view, 1, 2, 3, 0, 1, 0, -4, 5, 6)
+	var gview Mat4[float64]
+	SetViewMat4(&gview, Vec3[float64]{1, 2, 3}, Vec3[float64]{0, 1, 0}, Vec3[float64]{-4, 5, 6})
+	if got := gview.Matrix4(); !matrix4Close(&got, &view, 0.000001) {
+		t.Errorf("view: expected=%v got=%v", view, got)
+	}
+
+	var persp Matrix4
+	SetPerspectiveMatrixClip(&persp, ClipSpaceVulkan, math.Pi/3, 1.5, 0.1, 100)
+	var gpersp Mat4[float32]
+	SetPerspectiveMat4(&gpersp, ClipSpaceVulkan, math.Pi/3, 1.5, 0.1, 100)
+	if got := gpersp.Matrix4(); !Matrix4Equal(&got, &persp) {
+		t.Errorf("perspective: expected=%v got=%v", persp, got)
+	}
+
+	var model Matrix4
+	SetModelMatrix(&model, 0, 0.6, -0.8, 1, 0, 0, 7, -8, 9)
+	model.Translate(1, 2, 3, 1)
+	model.Scale(2, 3, 4, 1)
+	var gmodel Mat4[float64]
+	SetModelMat4(&gmodel, Vec3[float64]{0, 0.6, -0.8}, Vec3[float64]{1, 0, 0}, Vec3[float64]{7, -8, 9})
+	gmodel.Translate(Vec3[float64]{1, 2, 3})
+	gmodel.Scale(Vec3[float64]{2, 3, 4})
+	if got := gmodel.Matrix4(); !matrix4Close(&got, &model, 0.00001) {
+		t.Errorf("model: expected=%v got=%v", model, got)
+	}
+}
+
+func TestMat4Float64Precision(t *testing.T) {
+	// object far from the origin, as in large worlds
+	var m Mat4[float64]
+	SetModelMat4(&m, Vec3[float64]{0, 0, -1}, Vec3[float64]{0, 1, 0}, Vec3[float64]{1e7, 0, 1e7})
+	p := Vec3[float64]{0.125, 0.25, 0.5}
+
+	world := m.TransformPoint(p)
+	inv := m
+	if err := inv.Invert(); err != nil {
+		t.Fatalf("invert: %v", err)
+	}
+	if back := inv.TransformPoint(world); back.Distance(p) > 1e-9 {
+		t.Errorf("float64 round trip: expected=%v got=%v", p, back)
+	}
+
+	m32 := ConvertMat4[float32](&m)
+	world32 := m32.TransformPoint(ConvertVec3[float32](p))
+	if d := ConvertVec3[float64](world32).Distance(world); d < 0.1 {
+		t.Errorf("float32 expected to lose the sub-unit offset at 1e7, error=%v", d)
+	}
+
+	if m.Transpose(); m.At(3, 0) != 1e7 {
+		t.Errorf("transpose: expected at(3,0)=1e7 got=%v", m.At(3, 0))
+	}
+	id := NewMat4Identity[float64]()
+	if !id.Identity() || id.Determinant() != 1 {
+		t.Errorf("identity: %v", id)
+	}
+}
+
+func TestMat4OutOfRange(t *testing.T) {
+	m := NewMat4Identity[float64]()
+	for _, call := range []func(){
+		func() { m.At(4, 0) },
+		func() { m.At(0, -1) },
+		func() { m.Set(-1, 0, 1) },
+		func() { m.Set(0, 4, 1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for out of range index")
+				}
+			}()
+			call()
+		}()
+	}
+	if !m.Identity() {
+		t.Errorf("matrix changed: %v", m)
+	}
+}
+
+func testPerspectiveDivideZeroW[T Float](t *testing.T) {
+	if got, want := (Vec4[T]{1, 2, 3, 0}).PerspectiveDivide(), (Vec3[T]{1, 2, 3}); got != want {
+		t.Errorf("%T: w=0: expected=%v got=%v", got.X, want, got)
+	}
+
+	// maps every point to w=0: the result must match Matrix4.TransformPoint
+	var m Mat4[T]
+	m.data[0], m.data[5], m.data[10] = 1, 1, 1
+	ref := m.Matrix4()
+	want := ref.TransformPoint(Vector3{1, 2, 3})
+	if got := m.TransformPoint(Vec3[T]{1, 2, 3}); got.Vector3() != want {
+		t.Errorf("%T: transform point: expected=%v got=%v", got.X, want, got)
+	}
+}
+
+func TestVec4PerspectiveDivideZeroW(t *testing.T) {
+	testPerspectiveDivideZeroW[float32](t)
+	testPerspectiveDivideZeroW[float64](t)
+}
+
+func TestVecConversions(t *testing.T) {
+	v := Vector3{1, 2, 3}
+	g := NewVec3[float32](v)
+	if got := g.Vector3(); got != v {
+		t.Errorf("vector3: expected=%v got=%v", v, got)
+	}
+	if got := g.Cross(Vec3[float32]{0, 0, 1}); got != (Vec3[float32]{2, -1, 0}) {
+		t.Errorf("cross: got=%v", got)
+	}
+	if got := NewVec2[float64](Vector2{3, 4}).Length(); got != 5 {
+		t.Errorf("length: expected=5 got=%v", got)
+	}
+	if got := ConvertVec4[float64](Vec4[float32]{2, 4, 6, 2}).PerspectiveDivide(); got != (Vec3[float64]{1, 2, 3}) {
+		t.Errorf("perspective divide: got=%v", got)
+	}
+}
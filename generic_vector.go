@@ -0,0 +1,227 @@
+package goglmath
+
+import (
+	"math"
+)
+
+// Float is the constraint for the element type of the generic matrix and vector types.
+// float32 is GPU-ready; float64 suits simulation and large-world coordinates.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Vec2 is a generic 2-component vector.
+type Vec2[T Float] struct {
+	X, Y T
+}
+
+// Vec3 is a generic 3-component vector.
+type Vec3[T Float] struct {
+	X, Y, Z T
+}
+
+// Vec4 is a generic 4-component vector.
+type Vec4[T Float] struct {
+	X, Y, Z, W T
+}
+
+// Add returns v+u.
+func (v Vec2[T]) Add(u Vec2[T]) Vec2[T] {
+	return Vec2[T]{v.X + u.X, v.Y + u.Y}
+}
+
+// Sub returns v-u.
+func (v Vec2[T]) Sub(u Vec2[T]) Vec2[T] {
+	return Vec2[T]{v.X - u.X, v.Y - u.Y}
+}
+
+// Scale returns v*s.
+func (v Vec2[T]) Scale(s T) Vec2[T] {
+	return Vec2[T]{v.X * s, v.Y * s}
+}
+
+// Dot returns the dot product.
+func (v Vec2[T]) Dot(u Vec2[T]) T {
+	return v.X*u.X + v.Y*u.Y
+}
+
+// Length returns the length.
+func (v Vec2[T]) Length() T {
+	return T(math.Sqrt(float64(v.Dot(v))))
+}
+
+// Normalize returns the unit vector. The zero vector is returned unchanged.
+func (v Vec2[T]) Normalize() Vec2[T] {
+	length := v.Length()
+	if length == 0 {
+		return v
+	}
+	return v.Scale(1 / length)
+}
+
+// Lerp interpolates linearly from v to u.
+func (v Vec2[T]) Lerp(u Vec2[T], t T) Vec2[T] {
+	return v.Add(u.Sub(v).Scale(t))
+}
+
+// Vector2 converts to Vector2.
+func (v Vec2[T]) Vector2() Vector2 {
+	return Vector2{float64(v.X), float64(v.Y)}
+}
+
+// Add returns v+u.
+func (v Vec3[T]) Add(u Vec3[T]) Vec3[T] {
+	return Vec3[T]{v.X + u.X, v.Y + u.Y, v.Z + u.Z}
+}
+
+// Sub returns v-u.
+func (v Vec3[T]) Sub(u Vec3[T]) Vec3[T] {
+	return Vec3[T]{v.X - u.X, v.Y - u.Y, v.Z - u.Z}
+}
+
+// Scale returns v*s.
+func (v Vec3[T]) Scale(s T) Vec3[T] {
+	return Vec3[T]{v.X * s, v.Y * s, v.Z * s}
+}
+
+// Dot returns the dot product.
+func (v Vec3[T]) Dot(u Vec3[T]) T {
+	return v.X*u.X + v.Y*u.Y + v.Z*u.Z
+}
+
+// Cross returns the cross product.
+func (v Vec3[T]) Cross(u Vec3[T]) Vec3[T] {
+	return Vec3[T]{
+		v.Y*u.Z - v.Z*u.Y,
+		v.Z*u.X - v.X*u.Z,
+		v.X*u.Y - v.Y*u.X,
+	}
+}
+
+// Length returns the length.
+func (v Vec3[T]) Length() T {
+	return T(math.Sqrt(float64(v.Dot(v))))
+}
+
+// Distance returns the distance between points v and u.
+func (v Vec3[T]) Distance(u Vec3[T]) T {
+	return v.Sub(u).Length()
+}
+
+// Normalize returns the unit vector. The zero vector is returned unchanged.
+func (v Vec3[T]) Normalize() Vec3[T] {
+	length := v.Length()
+	if length == 0 {
+		return v
+	}
+	return v.Scale(1 / length)
+}
+
+// Lerp interpolates linearly from v to u.
+func (v Vec3[T]) Lerp(u Vec3[T], t T) Vec3[T] {
+	return v.Add(u.Sub(v).Scale(t))
+}
+
+// Vec4 extends the vector with w.
+func (v Vec3[T]) Vec4(w T) Vec4[T] {
+	return Vec4[T]{v.X, v.Y, v.Z, w}
+}
+
+// Vector3 converts to Vector3.
+func (v Vec3[T]) Vector3() Vector3 {
+	return Vector3{float64(v.X), float64(v.Y), float64(v.Z)}
+}
+
+// Add returns v+u.
+func (v Vec4[T]) Add(u Vec4[T]) Vec4[T] {
+	return Vec4[T]{v.X + u.X, v.Y + u.Y, v.Z + u.Z, v.W + u.W}
+}
+
+// Sub returns v-u.
+func (v Vec4[T]) Sub(u Vec4[T]) Vec4[T] {
+	return Vec4[T]{v.X - u.X, v.Y - u.Y, v.Z - u.Z, v.W - u.W}
+}
+
+// Scale returns v*s.
+func (v Vec4[T]) Scale(s T) Vec4[T] {
+	return Vec4[T]{v.X * s, v.Y * s, v.Z * s, v.W * s}
+}
+
+// Dot returns the dot product.
+func (v Vec4[T]) Dot(u Vec4[T]) T {
+	return v.X*u.X + v.Y*u.Y + v.Z*u.Z + v.W*u.W
+}
+
+// Length returns the length.
+func (v Vec4[T]) Length() T {
+	return T(math.Sqrt(float64(v.Dot(v))))
+}
+
+// Normalize returns the unit vector. The zero vector is returned unchanged.
+func (v Vec4[T]) Normalize() Vec4[T] {
+	length := v.Length()
+	if length == 0 {
+		return v
+	}
+	return v.Scale(1 / length)
+}
+
+// Lerp interpolates linearly from v to u.
+func (v Vec4[T]) Lerp(u Vec4[T], t T) Vec4[T] {
+	return v.Add(u.Sub(v).Scale(t))
+}
+
+// Vec3 drops w.
+func (v Vec4[T]) Vec3() Vec3[T] {
+	return Vec3[T]{v.X, v.Y, v.Z}
+}
+
+// PerspectiveDivide returns x/w y/w z/w.
+// w=0 (a direction) is returned without division, as Vector4.PerspectiveDivide.
+func (v Vec4[T]) PerspectiveDivide() Vec3[T] {
+	if v.W == 0 {
+		return v.Vec3()
+	}
+	return Vec3[T]{v.X / v.W, v.Y / v.W, v.Z / v.W}
+}
+
+// Vector4 converts to Vector4.
+func (v Vec4[T]) Vector4() Vector4 {
+	return Vector4{float64(v.X), float64(v.Y), float64(v.Z), float64(v.W)}
+}
+
+// NewVec2 converts from Vector2.
+func NewVec2[T Float](v Vector2) Vec2[T] {
+	return Vec2[T]{T(v.X), T(v.Y)}
+}
+
+// NewVec3 converts from Vector3.
+func NewVec3[T Float](v Vector3) Vec3[T] {
+	return Vec3[T]{T(v.X), T(v.Y), T(v.Z)}
+}
+
+// NewVec4 converts from Vector4.
+func NewVec4[T Float](v Vector4) Vec4[T] {
+	return Vec4[T]{T(v.X), T(v.Y), T(v.Z), T(v.W)}
+}
+
+// ConvertVec2 converts between precisions.
+//
+// v32 := ConvertVec2[float32](v64)
+func ConvertVec2[D, S Float](v Vec2[S]) Vec2[D] {
+	return Vec2[D]{D(v.X), D(v.Y)}
+}
+
+// ConvertVec3 converts between precisions.
+//
+// v32 := ConvertVec3[float32](v64)
+func ConvertVec3[D, S Float](v Vec3[S]) Vec3[D] {
+	return Vec3[D]{D(v.X), D(v.Y), D(v.Z)}
+}
+
+// ConvertVec4 converts between precisions.
+//
+// v32 := ConvertVec4[float32](v64)
+func ConvertVec4[D, S Float](v Vec4[S]) Vec4[D] {
+	return Vec4[D]{D(v.X), D(v.Y), D(v.Z), D(v.W)}
+}
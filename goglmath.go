@@ -157,88 +157,207 @@ func (m *Matrix4) Invert() error {
 
 // CopyInverseFrom sets the matrix as inverse of another source matrix.
 func (m *Matrix4) CopyInverseFrom(src *Matrix4) error {
-	a00 := src.data[0]
-	a01 := src.data[1]
-	a02 := src.data[2]
-	a03 := src.data[3]
-	a10 := src.data[4]
-	a11 := src.data[5]
-	a12 := src.data[6]
-	a13 := src.data[7]
-	a20 := src.data[8]
-	a21 := src.data[9]
-	a22 := src.data[10]
-	a23 := src.data[11]
-	a30 := src.data[12]
-	a31 := src.data[13]
-	a32 := src.data[14]
-	a33 := src.data[15]
-
-	b00 := a00*a11 - a01*a10
-	b01 := a00*a12 - a02*a10
-	b02 := a00*a13 - a03*a10
-	b03 := a01*a12 - a02*a11
-	b04 := a01*a13 - a03*a11
-	b05 := a02*a13 - a03*a12
-	b06 := a20*a31 - a21*a30
-	b07 := a20*a32 - a22*a30
-	b08 := a20*a33 - a23*a30
-	b09 := a21*a32 - a22*a31
-	b10 := a21*a33 - a23*a31
-	b11 := a22*a33 - a23*a32
-
-	det := b00*b11 - b01*b10 + b02*b09 + b03*b08 - b04*b07 + b05*b06
-	if det == 0.0 {
+	if inverse4(&m.data, &src.data) == 0 {
 		m.CopyFrom(src)
 		return errors.New("copyInverseFrom: null determinant")
 	}
+	return nil
+}
+
+// InvertDeterminant inverts the matrix, returning the determinant of the original matrix.
+func (m *Matrix4) InvertDeterminant() (float64, error) {
+	return m.CopyInverseDeterminantFrom(m)
+}
+
+// CopyInverseDeterminantFrom sets the matrix as inverse of another source matrix, returning the determinant of the source matrix.
+// The inverse is the same computed by CopyInverseFrom.
+func (m *Matrix4) CopyInverseDeterminantFrom(src *Matrix4) (float64, error) {
+	det := inverse4(&m.data, &src.data)
+	if det == 0.0 {
+		m.CopyFrom(src)
+		return 0, errors.New("copyInverseDeterminantFrom: null determinant")
+	}
+	return float64(det), nil
+}
+
+// InvertAffine inverts the affine matrix.
+// See CopyAffineInverseFrom.
+func (m *Matrix4) InvertAffine() error {
+	return m.CopyAffineInverseFrom(m)
+}
+
+// CopyAffineInverseFrom sets the matrix as inverse of another source affine matrix.
+// The last row of src is assumed to be 0 0 0 1, as in matrices built by SetModelMatrix.
+// Only the upper 3x3 is inverted, in float64, then the translation is rotated by the inverse:
+// inverse(A t) = inverse(A) -inverse(A)*t
+func (m *Matrix4) CopyAffineInverseFrom(src *Matrix4) error {
+	a00 := float64(src.data[0])
+	a01 := float64(src.data[1])
+	a02 := float64(src.data[2])
+	a10 := float64(src.data[4])
+	a11 := float64(src.data[5])
+	a12 := float64(src.data[6])
+	a20 := float64(src.data[8])
+	a21 := float64(src.data[9])
+	a22 := float64(src.data[10])
+	tx := float64(src.data[12])
+	ty := float64(src.data[13])
+	tz := float64(src.data[14])
+
+	b01 := a22*a11 - a12*a21
+	b11 := -a22*a10 + a12*a20
+	b21 := a21*a10 - a11*a20
+
+	det := a00*b01 + a01*b11 + a02*b21
+	if det == 0.0 {
+		m.CopyFrom(src)
+		return errors.New("copyAffineInverseFrom: null determinant")
+	}
 	invDet := 1.0 / det
 
-	m.data[0] = (a11*b11 - a12*b10 + a13*b09) * invDet
-	m.data[1] = (-a01*b11 + a02*b10 - a03*b09) * invDet
-	m.data[2] = (a31*b05 - a32*b04 + a33*b03) * invDet
-	m.data[3] = (-a21*b05 + a22*b04 - a23*b03) * invDet
-	m.data[4] = (-a10*b11 + a12*b08 - a13*b07) * invDet
-	m.data[5] = (a00*b11 - a02*b08 + a03*b07) * invDet
-	m.data[6] = (-a30*b05 + a32*b02 - a33*b01) * invDet
-	m.data[7] = (a20*b05 - a22*b02 + a23*b01) * invDet
-	m.data[8] = (a10*b10 - a11*b08 + a13*b06) * invDet
-	m.data[9] = (-a00*b10 + a01*b08 - a03*b06) * invDet
-	m.data[10] = (a30*b04 - a31*b02 + a33*b00) * invDet
-	m.data[11] = (-a20*b04 + a21*b02 - a23*b00) * invDet
-	m.data[12] = (-a10*b09 + a11*b07 - a12*b06) * invDet
-	m.data[13] = (a00*b09 - a01*b07 + a02*b06) * invDet
-	m.data[14] = (-a30*b03 + a31*b01 - a32*b00) * invDet
-	m.data[15] = (a20*b03 - a21*b01 + a22*b00) * invDet
+	i00 := b01 * invDet
+	i01 := (-a22*a01 + a02*a21) * invDet
+	i02 := (a12*a01 - a02*a11) * invDet
+	i10 := b11 * invDet
+	i11 := (a22*a00 - a02*a20) * invDet
+	i12 := (-a12*a00 + a02*a10) * invDet
+	i20 := b21 * invDet
+	i21 := (-a21*a00 + a01*a20) * invDet
+	i22 := (a11*a00 - a01*a10) * invDet
+
+	m.data[0] = float32(i00)
+	m.data[1] = float32(i01)
+	m.data[2] = float32(i02)
+	m.data[3] = 0
+	m.data[4] = float32(i10)
+	m.data[5] = float32(i11)
+	m.data[6] = float32(i12)
+	m.data[7] = 0
+	m.data[8] = float32(i20)
+	m.data[9] = float32(i21)
+	m.data[10] = float32(i22)
+	m.data[11] = 0
+	m.data[12] = float32(-(i00*tx + i10*ty + i20*tz))
+	m.data[13] = float32(-(i01*tx + i11*ty + i21*tz))
+	m.data[14] = float32(-(i02*tx + i12*ty + i22*tz))
+	m.data[15] = 1
 
 	return nil
 }
 
+// InvertRigid inverts the rigid-body matrix.
+// See CopyRigidInverseFrom.
+func (m *Matrix4) InvertRigid() {
+	m.CopyRigidInverseFrom(m)
+}
+
+// CopyRigidInverseFrom sets the matrix as inverse of another source rigid-body matrix.
+// src is assumed to be a rotation followed by translation, as in matrices built by SetViewMatrix,
+// or by SetModelMatrix without scaling.
+// The rotation is transposed and the translation is negated and rotated by the transposed rotation:
+// inverse(R t) = transpose(R) -transpose(R)*t
+func (m *Matrix4) CopyRigidInverseFrom(src *Matrix4) {
+	r00 := src.data[0]
+	r01 := src.data[1]
+	r02 := src.data[2]
+	r10 := src.data[4]
+	r11 := src.data[5]
+	r12 := src.data[6]
+	r20 := src.data[8]
+	r21 := src.data[9]
+	r22 := src.data[10]
+	tx := float64(src.data[12])
+	ty := float64(src.data[13])
+	tz := float64(src.data[14])
+
+	m.data[0] = r00
+	m.data[1] = r10
+	m.data[2] = r20
+	m.data[3] = 0
+	m.data[4] = r01
+	m.data[5] = r11
+	m.data[6] = r21
+	m.data[7] = 0
+	m.data[8] = r02
+	m.data[9] = r12
+	m.data[10] = r22
+	m.data[11] = 0
+	m.data[12] = float32(-(float64(r00)*tx + float64(r01)*ty + float64(r02)*tz))
+	m.data[13] = float32(-(float64(r10)*tx + float64(r11)*ty + float64(r12)*tz))
+	m.data[14] = float32(-(float64(r20)*tx + float64(r21)*ty + float64(r22)*tz))
+	m.data[15] = 1
+}
+
+// InvertAuto inverts the matrix, choosing the cheapest path for its structure.
+// See CopyInverseAutoFrom.
+func (m *Matrix4) InvertAuto() error {
+	return m.CopyInverseAutoFrom(m)
+}
+
+// CopyInverseAutoFrom sets the matrix as inverse of another source matrix, choosing the cheapest path for its structure.
+// Rigid-body matrices use CopyRigidInverseFrom, other affine matrices use CopyAffineInverseFrom,
+// remaining matrices use CopyInverseFrom.
+// The structure is checked far more strictly than IsRigid and IsAffine do, since nearly affine matrices need the full inverse.
+func (m *Matrix4) CopyInverseAutoFrom(src *Matrix4) error {
+	switch {
+	case !src.affine():
+		return m.CopyInverseFrom(src)
+	case src.rigid():
+		m.CopyRigidInverseFrom(src)
+		return nil
+	}
+	return m.CopyAffineInverseFrom(src)
+}
+
+// Determinant calculates the determinant of the matrix.
+func (m *Matrix4) Determinant() float64 {
+	var adj [16]float32
+	return float64(adjugate4(&adj, &m.data))
+}
+
+// Adjugate replaces the matrix with its adjugate (transposed cofactor matrix).
+// For invertible matrices: adjugate = determinant * inverse
+func (m *Matrix4) Adjugate() {
+	adjugate4(&m.data, &m.data)
+}
+
+// CopyAdjugateFrom sets the matrix as adjugate of another source matrix.
+func (m *Matrix4) CopyAdjugateFrom(src *Matrix4) {
+	adjugate4(&m.data, &src.data)
+}
+
+// Transpose transposes the matrix.
+func (m *Matrix4) Transpose() {
+	d := &m.data
+	d[1], d[4] = d[4], d[1]
+	d[2], d[8] = d[8], d[2]
+	d[3], d[12] = d[12], d[3]
+	d[6], d[9] = d[9], d[6]
+	d[7], d[13] = d[13], d[7]
+	d[11], d[14] = d[14], d[11]
+}
+
+// CopyTransposeFrom sets the matrix as transpose of another source matrix.
+func (m *Matrix4) CopyTransposeFrom(src *Matrix4) {
+	if m == src {
+		m.Transpose()
+		return
+	}
+	for c := 0; c < 4; c++ {
+		for r := 0; r < 4; r++ {
+			m.data[r*4+c] = src.data[c*4+r]
+		}
+	}
+}
+
+// Trace returns the sum of the main diagonal elements.
+func (m *Matrix4) Trace() float64 {
+	return float64(m.data[0]) + float64(m.data[5]) + float64(m.data[10]) + float64(m.data[15])
+}
+
 // Transform multiples this matrix [m] by vector [x,y,z,w]
 func (m *Matrix4) Transform(x, y, z, w float64) (tx, ty, tz, tw float64) {
-	m0 := float64(m.data[0])
-	m1 := float64(m.data[1])
-	m2 := float64(m.data[2])
-	m3 := float64(m.data[3])
-	m4 := float64(m.data[4])
-	m5 := float64(m.data[5])
-	m6 := float64(m.data[6])
-	m7 := float64(m.data[7])
-	m8 := float64(m.data[8])
-	m9 := float64(m.data[9])
-	m10 := float64(m.data[10])
-	m11 := float64(m.data[11])
-	m12 := float64(m.data[12])
-	m13 := float64(m.data[13])
-	m14 := float64(m.data[14])
-	m15 := float64(m.data[15])
-
-	tx = m0*x + m4*y + m8*z + m12*w
-	ty = m1*x + m5*y + m9*z + m13*w
-	tz = m2*x + m6*y + m10*z + m14*w
-	tw = m3*x + m7*y + m11*z + m15*w
-
-	return
+	return transform4(&m.data, x, y, z, w)
 }
 
 // Rotate multiplies the matrix m by a rotation matrix built from specified forward and up vectors.
@@ -300,71 +419,22 @@ func (m *Matrix4) Scale(x, y, z, w float64) {
 
 // Multiply multiplies the matrix by another matrix.
 func (m *Matrix4) Multiply(n *Matrix4) {
-	m00 := m.data[0]
-	m01 := m.data[4]
-	m02 := m.data[8]
-	m03 := m.data[12]
-	m10 := m.data[1]
-	m11 := m.data[5]
-	m12 := m.data[9]
-	m13 := m.data[13]
-	m20 := m.data[2]
-	m21 := m.data[6]
-	m22 := m.data[10]
-	m23 := m.data[14]
-	m30 := m.data[3]
-	m31 := m.data[7]
-	m32 := m.data[11]
-	m33 := m.data[15]
-
-	n00 := n.data[0]
-	n01 := n.data[4]
-	n02 := n.data[8]
-	n03 := n.data[12]
-	n10 := n.data[1]
-	n11 := n.data[5]
-	n12 := n.data[9]
-	n13 := n.data[13]
-	n20 := n.data[2]
-	n21 := n.data[6]
-	n22 := n.data[10]
-	n23 := n.data[14]
-	n30 := n.data[3]
-	n31 := n.data[7]
-	n32 := n.data[11]
-	n33 := n.data[15]
-
-	m.data[0] = (m00 * n00) + (m01 * n10) + (m02 * n20) + (m03 * n30)
-	m.data[4] = (m00 * n01) + (m01 * n11) + (m02 * n21) + (m03 * n31)
-	m.data[8] = (m00 * n02) + (m01 * n12) + (m02 * n22) + (m03 * n32)
-	m.data[12] = (m00 * n03) + (m01 * n13) + (m02 * n23) + (m03 * n33)
-	m.data[1] = (m10 * n00) + (m11 * n10) + (m12 * n20) + (m13 * n30)
-	m.data[5] = (m10 * n01) + (m11 * n11) + (m12 * n21) + (m13 * n31)
-	m.data[9] = (m10 * n02) + (m11 * n12) + (m12 * n22) + (m13 * n32)
-	m.data[13] = (m10 * n03) + (m11 * n13) + (m12 * n23) + (m13 * n33)
-	m.data[2] = (m20 * n00) + (m21 * n10) + (m22 * n20) + (m23 * n30)
-	m.data[6] = (m20 * n01) + (m21 * n11) + (m22 * n21) + (m23 * n31)
-	m.data[10] = (m20 * n02) + (m21 * n12) + (m22 * n22) + (m23 * n32)
-	m.data[14] = (m20 * n03) + (m21 * n13) + (m22 * n23) + (m23 * n33)
-	m.data[3] = (m30 * n00) + (m31 * n10) + (m32 * n20) + (m33 * n30)
-	m.data[7] = (m30 * n01) + (m31 * n11) + (m32 * n21) + (m33 * n31)
-	m.data[11] = (m30 * n02) + (m31 * n12) + (m32 * n22) + (m33 * n32)
-	m.data[15] = (m30 * n03) + (m31 * n13) + (m32 * n23) + (m33 * n33)
+	multiply4(&m.data, &m.data, &n.data)
 }
 
 // DistanceSquared3 calculates the squared of the distance between two points.
 func DistanceSquared3(x1, y1, z1, x2, y2, z2 float64) float64 {
-	return LengthSquared3(x2-x1, y2-y1, z2-z1)
+	return Vector3{x1, y1, z1}.DistanceSquared(Vector3{x2, y2, z2})
 }
 
 // Distance3 calculates the distance between two points.
 func Distance3(x1, y1, z1, x2, y2, z2 float64) float64 {
-	return Length3(x2-x1, y2-y1, z2-z1)
+	return Vector3{x1, y1, z1}.Distance(Vector3{x2, y2, z2})
 }
 
 // Ortho3 reports if two vectors are orthogonal.
 func Ortho3(x1, y1, z1, x2, y2, z2 float64) bool {
-	return closeToZero(Dot3(x1, y1, z1, x2, y2, z2))
+	return Vector3{x1, y1, z1}.Ortho(Vector3{x2, y2, z2})
 }
 
 func closeToZero(f float64) bool {
@@ -373,31 +443,29 @@ func closeToZero(f float64) bool {
 
 // Cross3 calculates the cross (vector) product of two vectors.
 func Cross3(x1, y1, z1, x2, y2, z2 float64) (float64, float64, float64) {
-	return y1*z2 - z1*y2, z1*x2 - x1*z2, x1*y2 - y1*x2
+	c := Vector3{x1, y1, z1}.Cross(Vector3{x2, y2, z2})
+	return c.X, c.Y, c.Z
 }
 
 // Dot3 calculates the dot (scalar) product of two vectors.
 func Dot3(x1, y1, z1, x2, y2, z2 float64) float64 {
-	return x1*x2 + y1*y2 + z1*z2
+	return Vector3{x1, y1, z1}.Dot(Vector3{x2, y2, z2})
 }
 
 // LengthSquared3 calculates the squared length of a vector.
 func LengthSquared3(x, y, z float64) float64 {
-	return x*x + y*y + z*z // dot3(x,y,z,x,y,z)
+	return Vector3{x, y, z}.LengthSquared()
 }
 
 // Length3 calculates the length of a vector.
 func Length3(x, y, z float64) float64 {
-	return math.Sqrt(LengthSquared3(x, y, z))
+	return Vector3{x, y, z}.Length()
 }
 
 // Normalize3 calculates a normalized copy of a vector.
 func Normalize3(x, y, z float64) (float64, float64, float64) {
-	length := Length3(x, y, z)
-	if length == 0 {
-		return x, y, z // ugh
-	}
-	return x / length, y / length, z / length
+	n := Vector3{x, y, z}.Normalize()
+	return n.X, n.Y, n.Z
 }
 
 // SetRotationMatrix builds the rotation matrix.
@@ -537,14 +605,145 @@ func SetViewMatrix(viewMatrix *Matrix4, focusX, focusY, focusZ, upX, upY, upZ, p
 
 // SetPerspectiveMatrix builds the perspective projection matrix.
 func SetPerspectiveMatrix(perspectiveMatrix *Matrix4, fieldOfViewYRadians, aspectRatio, zNear, zFar float64) {
+	SetPerspectiveMatrixClip(perspectiveMatrix, ClipSpaceOpenGL, fieldOfViewYRadians, aspectRatio, zNear, zFar)
+}
+
+// SetPerspectiveMatrixClip builds the perspective projection matrix for the given clip space convention.
+func SetPerspectiveMatrixClip(perspectiveMatrix *Matrix4, clip ClipSpace, fieldOfViewYRadians, aspectRatio, zNear, zFar float64) {
 
 	f := math.Tan(math.Pi*0.5 - fieldOfViewYRadians*0.5) // = cotan(fieldOfViewYRadians/2)
+	d10, d14 := perspectiveDepth(clip, zNear, zFar)
+
+	setPerspective(perspectiveMatrix, clip, f/aspectRatio, f, 0, 0, d10, d14)
+}
+
+// perspectiveDepth calculates the depth scale and offset of the perspective projection matrix.
+func perspectiveDepth(clip ClipSpace, zNear, zFar float64) (depthScale, depthOffset float64) {
 	rangeInv := 1.0 / (zNear - zFar)
 
-	d0 := float32(f / aspectRatio)
-	d5 := float32(f)
-	d10 := float32((zNear + zFar) * rangeInv)
-	d14 := float32(zNear * zFar * rangeInv * 2.0)
+	switch {
+	case clip.ZeroToOneDepth && clip.ReverseZ:
+		depthScale = -zNear * rangeInv
+		depthOffset = -zNear * zFar * rangeInv
+	case clip.ZeroToOneDepth:
+		depthScale = zFar * rangeInv
+		depthOffset = zNear * zFar * rangeInv
+	case clip.ReverseZ:
+		depthScale = -(zNear + zFar) * rangeInv
+		depthOffset = -zNear * zFar * rangeInv * 2.0
+	default:
+		depthScale = (zNear + zFar) * rangeInv
+		depthOffset = zNear * zFar * rangeInv * 2.0
+	}
+
+	return
+}
+
+// SetFrustumMatrix builds the perspective projection matrix for the possibly off-center (asymmetric) frustum, like glFrustum.
+// left, right, bottom, top are the frustum extents on the near plane.
+// near, far are positive distances to the near and far planes.
+func SetFrustumMatrix(perspectiveMatrix *Matrix4, left, right, bottom, top, near, far float64) {
+	SetFrustumMatrixClip(perspectiveMatrix, ClipSpaceOpenGL, left, right, bottom, top, near, far)
+}
+
+// SetFrustumMatrixClip builds the off-center perspective projection matrix for the given clip space convention.
+// See SetFrustumMatrix.
+func SetFrustumMatrixClip(perspectiveMatrix *Matrix4, clip ClipSpace, left, right, bottom, top, near, far float64) {
+	rl := 1.0 / (right - left)
+	tb := 1.0 / (top - bottom)
+	d10, d14 := perspectiveDepth(clip, near, far)
+
+	setPerspective(perspectiveMatrix, clip, 2.0*near*rl, 2.0*near*tb, (right+left)*rl, (top+bottom)*tb, d10, d14)
+}
+
+// SetFovAnglesMatrix builds the off-center perspective projection matrix from the angles between the view direction and each side of the frustum.
+// Angles are in radians, angleLeft and angleDown are usually negative.
+// This is the field of view description used by head-mounted displays (e.g. OpenXR XrFovf).
+func SetFovAnglesMatrix(perspectiveMatrix *Matrix4, angleLeft, angleRight, angleDown, angleUp, near, far float64) {
+	SetFovAnglesMatrixClip(perspectiveMatrix, ClipSpaceOpenGL, angleLeft, angleRight, angleDown, angleUp, near, far)
+}
+
+// SetFovAnglesMatrixClip builds the off-center perspective projection matrix from field of view angles for the given clip space convention.
+// See SetFovAnglesMatrix.
+func SetFovAnglesMatrixClip(perspectiveMatrix *Matrix4, clip ClipSpace, angleLeft, angleRight, angleDown, angleUp, near, far float64) {
+	SetFrustumMatrixClip(perspectiveMatrix, clip,
+		near*math.Tan(angleLeft), near*math.Tan(angleRight),
+		near*math.Tan(angleDown), near*math.Tan(angleUp),
+		near, far)
+}
+
+// PerspectiveExtents calculates the near plane extents of the symmetric frustum built by SetPerspectiveMatrix.
+// The extents are suitable for SetFrustumMatrix.
+func PerspectiveExtents(fieldOfViewYRadians, aspectRatio, zNear float64) (left, right, bottom, top float64) {
+	top = zNear * math.Tan(fieldOfViewYRadians*0.5)
+	right = top * aspectRatio
+	return -right, right, -top, top
+}
+
+// TileExtents calculates the near plane extents of one tile of the frustum split into tilesX by tilesY tiles.
+// Tile 0,0 is the bottom-left tile.
+// Rendering every tile with SetFrustumMatrix produces a high-resolution image of the whole frustum.
+func TileExtents(left, right, bottom, top float64, tilesX, tilesY, tileX, tileY int) (tileLeft, tileRight, tileBottom, tileTop float64) {
+	width := (right - left) / float64(tilesX)
+	height := (top - bottom) / float64(tilesY)
+	tileLeft = left + width*float64(tileX)
+	tileBottom = bottom + height*float64(tileY)
+	return tileLeft, tileLeft + width, tileBottom, tileBottom + height
+}
+
+// SetPerspectiveMatrixInfinite builds the perspective projection matrix with far plane at infinity.
+func SetPerspectiveMatrixInfinite(perspectiveMatrix *Matrix4, fieldOfViewYRadians, aspectRatio, zNear float64) {
+	SetPerspectiveMatrixInfiniteClip(perspectiveMatrix, ClipSpaceOpenGL, fieldOfViewYRadians, aspectRatio, zNear)
+}
+
+// SetPerspectiveMatrixReverseZ builds the perspective projection matrix mapping near plane to depth 1 and far plane to depth 0.
+// The matrix follows the ClipSpaceReverseZ convention.
+func SetPerspectiveMatrixReverseZ(perspectiveMatrix *Matrix4, fieldOfViewYRadians, aspectRatio, zNear, zFar float64) {
+	SetPerspectiveMatrixClip(perspectiveMatrix, ClipSpaceReverseZ, fieldOfViewYRadians, aspectRatio, zNear, zFar)
+}
+
+// SetPerspectiveMatrixInfiniteReverseZ builds the perspective projection matrix mapping near plane to depth 1 and infinity to depth 0.
+// The matrix follows the ClipSpaceReverseZ convention.
+func SetPerspectiveMatrixInfiniteReverseZ(perspectiveMatrix *Matrix4, fieldOfViewYRadians, aspectRatio, zNear float64) {
+	SetPerspectiveMatrixInfiniteClip(perspectiveMatrix, ClipSpaceReverseZ, fieldOfViewYRadians, aspectRatio, zNear)
+}
+
+// SetPerspectiveMatrixInfiniteClip builds the perspective projection matrix with far plane at infinity for the given clip space convention.
+func SetPerspectiveMatrixInfiniteClip(perspectiveMatrix *Matrix4, clip ClipSpace, fieldOfViewYRadians, aspectRatio, zNear float64) {
+
+	f := math.Tan(math.Pi*0.5 - fieldOfViewYRadians*0.5) // = cotan(fieldOfViewYRadians/2)
+
+	// limits of the finite projection as zFar goes to infinity
+	var d10, d14 float64
+	switch {
+	case clip.ZeroToOneDepth && clip.ReverseZ:
+		d10 = 0
+		d14 = zNear
+	case clip.ZeroToOneDepth:
+		d10 = -1
+		d14 = -zNear
+	case clip.ReverseZ:
+		d10 = 1
+		d14 = zNear * 2.0
+	default:
+		d10 = -1
+		d14 = -zNear * 2.0
+	}
+
+	setPerspective(perspectiveMatrix, clip, f/aspectRatio, f, 0, 0, d10, d14)
+}
+
+func setPerspective(perspectiveMatrix *Matrix4, clip ClipSpace, x, y, offsetX, offsetY, depthScale, depthOffset float64) {
+	d0 := float32(x)
+	d5 := float32(y)
+	d8 := float32(offsetX)
+	d9 := float32(offsetY)
+	d10 := float32(depthScale)
+	d14 := float32(depthOffset)
+	if clip.FlipY {
+		d5 = -d5
+		d9 = -d9
+	}
 
 	perspectiveMatrix.data[0] = d0
 	perspectiveMatrix.data[1] = 0
@@ -554,8 +753,8 @@ func SetPerspectiveMatrix(perspectiveMatrix *Matrix4, fieldOfViewYRadians, aspec
 	perspectiveMatrix.data[5] = d5
 	perspectiveMatrix.data[6] = 0
 	perspectiveMatrix.data[7] = 0
-	perspectiveMatrix.data[8] = 0
-	perspectiveMatrix.data[9] = 0
+	perspectiveMatrix.data[8] = d8
+	perspectiveMatrix.data[9] = d9
 	perspectiveMatrix.data[10] = d10
 	perspectiveMatrix.data[11] = -1
 	perspectiveMatrix.data[12] = 0
@@ -564,35 +763,96 @@ func SetPerspectiveMatrix(perspectiveMatrix *Matrix4, fieldOfViewYRadians, aspec
 	perspectiveMatrix.data[15] = 0
 }
 
-/*
-camera = includes both the perspective and view transforms
-
-obj.coord. -> P*V*T*R*U*S -> clip coord -> divide by w -> NDC coord -> viewport transform -> window coord
-P*V*T*R*U*S = full transformation
-P = Perspective
-V = View (inverse of camera) built by setViewMatrix
-T*R = model transformation built by setModelMatrix
-T = Translation
-R = Rotation
-U = Undo Model Local Rotation
-S = Scaling
-*/
-func unproject(camera *Matrix4, viewportX, viewportWidth, viewportY, viewportHeight, pickX, pickY int, depth float64) (worldX, worldY, worldZ float64, err error) {
+// WindowOrigin selects the origin of window coordinates.
+type WindowOrigin int
 
-	// from screen coordinates to clip coordinates
-	pX := (2.0 * float64(pickX-viewportX) / float64(viewportWidth)) - 1.0
-	pY := (2.0 * float64(pickY-viewportY) / float64(viewportHeight)) - 1.0
-	pZ := 2.0*depth - 1.0
+// Window coordinate origins.
+const (
+	OriginBottomLeft WindowOrigin = iota // GL convention: window Y grows upwards
+	OriginTopLeft                        // window system convention: window Y grows downwards
+)
 
-	if pX < -1.0 || pX > 1.0 || pY < -1.0 || pY > 1.0 || pZ < -1.0 || pZ > 1.0 {
-		err = errors.New("unproject: pick point outside unit cube")
+// Project maps world coordinates to window coordinates.
+//
+// camera = includes both the perspective and view transforms
+// Input: viewport (viewportX, viewportWidth, viewportY, viewportHeight)
+// Input: depthRange (depthNear, depthFar), usually 0 and 1
+// Input: origin of window coordinates, also applied to viewportX,viewportY
+// Output: winX,winY,depth (winX,winY = window coord, with sub-pixel precision)
+//
+// obj.coord. -> P*V*T*R*U*S -> clip coord -> divide by w -> NDC coord -> viewport transform -> window coord
+// P*V*T*R*U*S = full transformation
+// P = Perspective
+// V = View (inverse of camera) built by setViewMatrix
+// T*R = model transformation built by setModelMatrix
+// T = Translation
+// R = Rotation
+// U = Undo Model Local Rotation
+// S = Scaling
+func Project(camera *Matrix4, viewportX, viewportWidth, viewportY, viewportHeight int, depthNear, depthFar float64, origin WindowOrigin, worldX, worldY, worldZ float64) (winX, winY, depth float64, err error) {
+	return ProjectClip(camera, ClipSpaceOpenGL, viewportX, viewportWidth, viewportY, viewportHeight, depthNear, depthFar, origin, worldX, worldY, worldZ)
+}
+
+// ProjectClip maps world coordinates to window coordinates for the given clip space convention.
+// See Project.
+func ProjectClip(camera *Matrix4, clip ClipSpace, viewportX, viewportWidth, viewportY, viewportHeight int, depthNear, depthFar float64, origin WindowOrigin, worldX, worldY, worldZ float64) (winX, winY, depth float64, err error) {
+
+	cx, cy, cz, cw := camera.Transform(worldX, worldY, worldZ, 1.0)
+	if cw == 0.0 {
+		err = errors.New("project: projected point with W=0")
 		return
 	}
 
-	// invertedCamera: clip coord -> undo perspective -> undo view -> world coord
-	var invertedCamera Matrix4
-	invertedCamera.CopyInverseFrom(camera)
-	vx, vy, vz, vw := invertedCamera.Transform(pX, pY, pZ, 1.0)
+	// from clip coordinates to NDC coordinates
+	invW := 1.0 / cw
+	ndcX := cx * invW
+	ndcY := cy * invW
+	ndcZ := cz * invW
+
+	// from NDC coordinates to window coordinates
+	winX = float64(viewportX) + .5*(ndcX+1.0)*float64(viewportWidth)
+	if (origin == OriginTopLeft) != clip.FlipY {
+		ndcY = -ndcY
+	}
+	winY = float64(viewportY) + .5*(ndcY+1.0)*float64(viewportHeight)
+	if clip.ZeroToOneDepth {
+		depth = depthNear + ndcZ*(depthFar-depthNear)
+	} else {
+		depth = .5 * (ndcZ*(depthFar-depthNear) + (depthFar + depthNear))
+	}
+
+	return
+}
+
+// Unproject maps window coordinates back to world coordinates.
+//
+// camera = includes both the perspective and view transforms
+// Input: viewport (viewportX, viewportWidth, viewportY, viewportHeight)
+// Input: depthRange (depthNear, depthFar), usually 0 and 1
+// Input: origin of window coordinates, also applied to viewportX,viewportY
+// Input: winX,winY,depth (winX,winY = window coord, depth within depthRange)
+//
+// obj.coord. -> P*V*T*R*U*S -> clip coord -> divide by w -> NDC coord -> viewport transform -> window coord
+// P*V*T*R*U*S = full transformation
+// P = Perspective
+// V = View (inverse of camera) built by setViewMatrix
+// T*R = model transformation built by setModelMatrix
+// T = Translation
+// R = Rotation
+// U = Undo Model Local Rotation
+// S = Scaling
+func Unproject(camera *Matrix4, viewportX, viewportWidth, viewportY, viewportHeight int, depthNear, depthFar float64, origin WindowOrigin, winX, winY, depth float64) (worldX, worldY, worldZ float64, err error) {
+	return UnprojectClip(camera, ClipSpaceOpenGL, viewportX, viewportWidth, viewportY, viewportHeight, depthNear, depthFar, origin, winX, winY, depth)
+}
+
+// UnprojectClip maps window coordinates back to world coordinates for the given clip space convention.
+// See Unproject.
+func UnprojectClip(camera *Matrix4, clip ClipSpace, viewportX, viewportWidth, viewportY, viewportHeight int, depthNear, depthFar float64, origin WindowOrigin, winX, winY, depth float64) (worldX, worldY, worldZ float64, err error) {
+
+	vx, vy, vz, vw, err := unprojectHomogeneous(camera, clip, viewportX, viewportWidth, viewportY, viewportHeight, depthNear, depthFar, origin, winX, winY, depth)
+	if err != nil {
+		return
+	}
 	if vw == 0.0 {
 		err = errors.New("unproject: unprojected pick point with W=0")
 		return
@@ -605,7 +865,48 @@ func unproject(camera *Matrix4, viewportX, viewportWidth, viewportY, viewportHei
 	return
 }
 
+// unprojectHomogeneous maps window coordinates back to homogeneous world coordinates.
+// W=0 means the window point unprojects to a point at infinity, such as the far plane of SetPerspectiveMatrixInfinite.
+func unprojectHomogeneous(camera *Matrix4, clip ClipSpace, viewportX, viewportWidth, viewportY, viewportHeight int, depthNear, depthFar float64, origin WindowOrigin, winX, winY, depth float64) (vx, vy, vz, vw float64, err error) {
+
+	if depthFar == depthNear {
+		err = errors.New("unproject: null depth range")
+		return
+	}
+
+	// from window coordinates to NDC coordinates
+	pX := (2.0 * (winX - float64(viewportX)) / float64(viewportWidth)) - 1.0
+	pY := (2.0 * (winY - float64(viewportY)) / float64(viewportHeight)) - 1.0
+	if (origin == OriginTopLeft) != clip.FlipY {
+		pY = -pY
+	}
+	var pZ, minZ float64
+	if clip.ZeroToOneDepth {
+		pZ = (depth - depthNear) / (depthFar - depthNear)
+	} else {
+		pZ = (2.0*depth - (depthFar + depthNear)) / (depthFar - depthNear)
+		minZ = -1.0
+	}
+
+	if pX < -1.0 || pX > 1.0 || pY < -1.0 || pY > 1.0 || pZ < minZ || pZ > 1.0 {
+		err = errors.New("unproject: pick point outside unit cube")
+		return
+	}
+
+	// invertedCamera: clip coord -> undo perspective -> undo view -> world coord
+	var invertedCamera Matrix4
+	if err = invertedCamera.CopyInverseFrom(camera); err != nil {
+		return
+	}
+	vx, vy, vz, vw = invertedCamera.Transform(pX, pY, pZ, 1.0)
+
+	return
+}
+
 // PickRay calculates points where pickX,pickY intersects near and far planes.
+// pickX,pickY and viewportX,viewportY are window system coordinates, with origin at top-left:
+// viewportY is the top edge of the viewport, as in ViewportTransform.
+// For bottom-left (GL) window coordinates, see Unproject.
 //
 // camera = includes both the perspective and view transforms
 // (camera: the func parameter)
@@ -620,44 +921,85 @@ func unproject(camera *Matrix4, viewportX, viewportWidth, viewportY, viewportHei
 // U = Undo Model Local Rotation
 // S = Scaling
 func PickRay(camera *Matrix4, viewportX, viewportWidth, viewportY, viewportHeight, pickX, pickY int) (nearX, nearY, nearZ, farX, farY, farZ float64, err error) {
+	return PickRayClip(camera, ClipSpaceOpenGL, viewportX, viewportWidth, viewportY, viewportHeight, pickX, pickY)
+}
+
+// PickRayClip calculates points where pickX,pickY intersects near and far planes for the given clip space convention.
+// See PickRay.
+// If the far plane is at infinity (SetPerspectiveMatrixInfinite), farX,farY,farZ is a finite point further along the pick ray, rather than on the far plane.
+func PickRayClip(camera *Matrix4, clip ClipSpace, viewportX, viewportWidth, viewportY, viewportHeight, pickX, pickY int) (nearX, nearY, nearZ, farX, farY, farZ float64, err error) {
+
+	nearDepth, farDepth := 0.0, 1.0
+	if clip.ReverseZ {
+		nearDepth, farDepth = farDepth, nearDepth
+	}
 
-	nearX, nearY, nearZ, err = unproject(camera, viewportX, viewportWidth, viewportY, viewportHeight, pickX, viewportHeight-pickY, 0.0)
+	nearX, nearY, nearZ, err = UnprojectClip(camera, clip, viewportX, viewportWidth, viewportY, viewportHeight, 0.0, 1.0, OriginTopLeft, float64(pickX), float64(pickY), nearDepth)
 	if err != nil {
 		return
 	}
 
-	farX, farY, farZ, err = unproject(camera, viewportX, viewportWidth, viewportY, viewportHeight, pickX, viewportHeight-pickY, 1.0)
+	vx, vy, vz, vw, err := unprojectHomogeneous(camera, clip, viewportX, viewportWidth, viewportY, viewportHeight, 0.0, 1.0, OriginTopLeft, float64(pickX), float64(pickY), farDepth)
+	if err != nil {
+		return
+	}
+	if vw == 0 {
+		// far plane at infinity: pick a finite point halfway in depth range.
+		// The test is exact: finite far planes, however distant, have w != 0.
+		farX, farY, farZ, err = UnprojectClip(camera, clip, viewportX, viewportWidth, viewportY, viewportHeight, 0.0, 1.0, OriginTopLeft, float64(pickX), float64(pickY), .5)
+		return
+	}
+	invW := 1.0 / vw
+	farX = vx * invW
+	farY = vy * invW
+	farZ = vz * invW
 
 	return
 }
 
 // ViewportTransform simulates the viewport transform.
-// ViewportTransform maps NDC coordinates to viewport coordinates.
+// ViewportTransform maps NDC coordinates to window coordinates.
 // Input: viewport (viewportX, viewportWidth, viewportY, viewportHeight)
 // Input: depthRange (depthNear, depthFar)
-// Output: x,y,depth (x,y = viewport coord)
+// Output: x,y,depth (x,y = window coord)
+//
+// viewportX,viewportY and x,y are window system coordinates, with origin at top-left,
+// as in PickRay: viewportY is the top edge of the viewport.
+// For bottom-left (GL) window coordinates and sub-pixel precision, see Project.
 func ViewportTransform(viewportX, viewportWidth, viewportY, viewportHeight int, depthNear, depthFar, ndcX, ndcY, ndcZ float64) (int, int, float64) {
 	return viewportTransform2(viewportX, viewportWidth, viewportY, viewportHeight, depthNear, depthFar, ndcX, ndcY, ndcZ)
 }
 
+// ViewportTransformClip simulates the viewport transform for the given clip space convention.
+// See ViewportTransform.
+func ViewportTransformClip(clip ClipSpace, viewportX, viewportWidth, viewportY, viewportHeight int, depthNear, depthFar, ndcX, ndcY, ndcZ float64) (int, int, float64) {
+	if clip.FlipY {
+		ndcY = -ndcY
+	}
+	if clip.ZeroToOneDepth {
+		ndcZ = 2.0*ndcZ - 1.0
+	}
+	return viewportTransform2(viewportX, viewportWidth, viewportY, viewportHeight, depthNear, depthFar, ndcX, ndcY, ndcZ)
+}
+
 func viewportTransform1(viewportX, viewportWidth, viewportY, viewportHeight int, depthNear, depthFar, ndcX, ndcY, ndcZ float64) (int, int, float64) {
 	halfWidth := float64(viewportWidth) / 2.0
 	halfHeight := float64(viewportHeight) / 2.0
 	vx := roundToInt(ndcX*halfWidth+halfWidth) + viewportX
-	vy := roundToInt(ndcY*halfHeight+halfHeight) + viewportY
+	vy := roundToInt(ndcY*halfHeight + halfHeight)
 	depth := (ndcZ*(depthFar-depthNear) + (depthFar + depthNear)) / 2.0
 
-	return vx, viewportHeight - vy, depth
+	return vx, viewportY + viewportHeight - vy, depth
 }
 
 func viewportTransform2(viewportX, viewportWidth, viewportY, viewportHeight int, depthNear, depthFar, ndcX, ndcY, ndcZ float64) (int, int, float64) {
 	halfWidth := .5 * float64(viewportWidth)
 	halfHeight := .5 * float64(viewportHeight)
 	vx := roundToInt(ndcX*halfWidth+halfWidth) + viewportX
-	vy := roundToInt(ndcY*halfHeight+halfHeight) + viewportY
+	vy := roundToInt(ndcY*halfHeight + halfHeight)
 	depth := .5 * (ndcZ*(depthFar-depthNear) + (depthFar + depthNear))
 
-	return vx, viewportHeight - vy, depth
+	return vx, viewportY + viewportHeight - vy, depth
 }
 
 func round(a float64) float64 {
@@ -684,23 +1026,50 @@ SetOrthoMatrix(m,-1,1,-1,1,-1,1): flip Z (this is the usual ortho projection)
 SetOrthoMatrix(m,-1,1,-1,1,1,-1): identity
 */
 func SetOrthoMatrix(orthoMatrix *Matrix4, left, right, bottom, top, near, far float64) {
+	SetOrthoMatrixClip(orthoMatrix, ClipSpaceOpenGL, left, right, bottom, top, near, far)
+}
+
+// SetOrthoMatrixClip builds matrix for orthographic projection for the given clip space convention.
+// See SetOrthoMatrix.
+func SetOrthoMatrixClip(orthoMatrix *Matrix4, clip ClipSpace, left, right, bottom, top, near, far float64) {
 	lr := 1.0 / (left - right)
 	bt := 1.0 / (bottom - top)
 	nf := 1.0 / (near - far)
+	d5 := float32(-2.0 * bt)
+	d13 := float32((top + bottom) * bt)
+	var d10, d14 float32
+	switch {
+	case clip.ZeroToOneDepth && clip.ReverseZ:
+		d10 = float32(-nf)
+		d14 = float32(1.0 - near*nf)
+	case clip.ZeroToOneDepth:
+		d10 = float32(nf)
+		d14 = float32(near * nf)
+	case clip.ReverseZ:
+		d10 = float32(-2.0 * nf)
+		d14 = float32(-(far + near) * nf)
+	default:
+		d10 = float32(2.0 * nf)
+		d14 = float32((far + near) * nf)
+	}
+	if clip.FlipY {
+		d5 = -d5
+		d13 = -d13
+	}
 	orthoMatrix.data[0] = float32(-2.0 * lr)
 	orthoMatrix.data[1] = 0
 	orthoMatrix.data[2] = 0
 	orthoMatrix.data[3] = 0
 	orthoMatrix.data[4] = 0
-	orthoMatrix.data[5] = float32(-2.0 * bt)
+	orthoMatrix.data[5] = d5
 	orthoMatrix.data[6] = 0
 	orthoMatrix.data[7] = 0
 	orthoMatrix.data[8] = 0
 	orthoMatrix.data[9] = 0
-	orthoMatrix.data[10] = float32(2.0 * nf)
+	orthoMatrix.data[10] = d10
 	orthoMatrix.data[11] = 0
 	orthoMatrix.data[12] = float32((left + right) * lr)
-	orthoMatrix.data[13] = float32((top + bottom) * bt)
-	orthoMatrix.data[14] = float32((far + near) * nf)
+	orthoMatrix.data[13] = d13
+	orthoMatrix.data[14] = d14
 	orthoMatrix.data[15] = 1
 }
@@ -1,6 +1,7 @@
 package goglmath
 
 import (
+	"math"
 	"testing"
 )
 
@@ -154,6 +155,126 @@ func TestRotate(t *testing.T) {
 	}
 }
 
+func TestProjectUnproject(t *testing.T) {
+	var P, V Matrix4
+	SetPerspectiveMatrix(&P, math.Pi/3, 1.5, 1, 100)
+	SetViewMatrix(&V, 0, 0, 0, 0, 1, 0, 3, 4, 5)
+	P.Multiply(&V)
+
+	for _, origin := range []WindowOrigin{OriginBottomLeft, OriginTopLeft} {
+		winX, winY, depth, err := Project(&P, 10, 300, 20, 200, 0, 1, origin, .5, -.25, 1)
+		if err != nil {
+			t.Fatalf("origin=%v: project: %v", origin, err)
+		}
+		x, y, z, err := Unproject(&P, 10, 300, 20, 200, 0, 1, origin, winX, winY, depth)
+		if err != nil {
+			t.Fatalf("origin=%v: unproject: %v", origin, err)
+		}
+		if d := Distance3(x, y, z, .5, -.25, 1); d > 0.0001 {
+			t.Errorf("origin=%v: round trip: expected=.5,-.25,1 got=%v,%v,%v", origin, x, y, z)
+		}
+	}
+}
+
+func TestProjectOrigin(t *testing.T) {
+	I := NewMatrix4Identity()
+	_, bottomLeft, _, _ := Project(&I, 0, 100, 50, 100, 0, 1, OriginBottomLeft, 0, .5, 0)
+	if bottomLeft != 125 {
+		t.Errorf("bottom-left: expected=125 got=%v", bottomLeft)
+	}
+	_, topLeft, _, _ := Project(&I, 0, 100, 50, 100, 0, 1, OriginTopLeft, 0, .5, 0)
+	if topLeft != 75 {
+		t.Errorf("top-left: expected=75 got=%v", topLeft)
+	}
+}
+
+func TestPickRayViewportY(t *testing.T) {
+	var P Matrix4
+	SetPerspectiveMatrix(&P, math.Pi/2, 1, 1, 10)
+
+	// bottom half of a split-screen window: viewport starts at window Y=100
+	nearX, nearY, nearZ, farX, farY, farZ, err := PickRay(&P, 0, 100, 100, 100, 50, 150)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !closeToZero(nearX) || !closeToZero(nearY) || !closeToZero(nearZ+1) {
+		t.Errorf("near: expected=0,0,-1 got=%v,%v,%v", nearX, nearY, nearZ)
+	}
+	if !closeToZero(farX) || !closeToZero(farY) || math.Abs(farZ+10) > 0.0001 {
+		t.Errorf("far: expected=0,0,-10 got=%v,%v,%v", farX, farY, farZ)
+	}
+}
+
+func TestPickRayLargeFar(t *testing.T) {
+	for _, far := range []float64{1e6, 1e7} {
+		var P Matrix4
+		SetPerspectiveMatrix(&P, math.Pi/3, 1.5, 1, far)
+		_, _, _, farX, farY, farZ, err := PickRay(&P, 0, 100, 0, 100, 50, 50)
+		if err != nil {
+			t.Fatalf("far=%v: unexpected error: %v", far, err)
+		}
+		// float32 matrix elements shift such a distant far plane by a few percent
+		if !closeToZero(farX/far) || !closeToZero(farY/far) || math.Abs(-farZ/far-1) > 0.2 {
+			t.Errorf("far=%v: expected point on far plane, got=%v,%v,%v", far, farX, farY, farZ)
+		}
+	}
+}
+
+func TestViewportTransformMatchesPickRay(t *testing.T) {
+	var P Matrix4
+	SetPerspectiveMatrix(&P, math.Pi/2, 1, 1, 10)
+
+	// bottom half of a split-screen window: viewport starts at window Y=100
+	nearX, nearY, nearZ, _, _, _, err := PickRay(&P, 0, 100, 100, 100, 25, 125)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ndc := P.TransformPoint(Vector3{nearX, nearY, nearZ})
+	x, y, _ := ViewportTransform(0, 100, 100, 100, 0, 1, ndc.X, ndc.Y, ndc.Z)
+	if x != 25 || y != 125 {
+		t.Errorf("expected=25,125 got=%v,%v", x, y)
+	}
+}
+
+func TestFrustumMatrixSymmetric(t *testing.T) {
+	var P, F Matrix4
+	SetPerspectiveMatrix(&P, math.Pi/3, 1.5, 1, 100)
+	left, right, bottom, top := PerspectiveExtents(math.Pi/3, 1.5, 1)
+	SetFrustumMatrix(&F, left, right, bottom, top, 1, 100)
+	if !matrix4Close(&P, &F, 0.000001) {
+		t.Errorf("mismatch: perspective=%v frustum=%v", P, F)
+	}
+}
+
+func TestFrustumMatrixOffCenter(t *testing.T) {
+	var F Matrix4
+	SetFrustumMatrix(&F, -1, 3, 0, 2, 2, 10)
+	bottomLeft := F.TransformPoint(Vector3{-1, 0, -2})
+	if !vector3Close(bottomLeft, Vector3{-1, -1, -1}) {
+		t.Errorf("near-bottom-left: expected=-1,-1,-1 got=%v", bottomLeft)
+	}
+	topRight := F.TransformPoint(Vector3{15, 10, -10}) // near extents scaled by far/near
+	if !vector3Close(topRight, Vector3{1, 1, 1}) {
+		t.Errorf("far-top-right: expected=1,1,1 got=%v", topRight)
+	}
+}
+
+func TestFovAnglesMatrix(t *testing.T) {
+	var A, F Matrix4
+	SetFovAnglesMatrix(&A, -math.Pi/4, math.Atan(.5), -math.Atan(.25), math.Pi/4, 2, 10)
+	SetFrustumMatrix(&F, -2, 1, -.5, 2, 2, 10)
+	if !matrix4Close(&A, &F, 0.000001) {
+		t.Errorf("mismatch: angles=%v frustum=%v", A, F)
+	}
+}
+
+func TestTileExtents(t *testing.T) {
+	left, right, bottom, top := TileExtents(-4, 4, -2, 2, 4, 2, 3, 1)
+	if left != 2 || right != 4 || bottom != 0 || top != 2 {
+		t.Errorf("expected=2,4,0,2 got=%v,%v,%v,%v", left, right, bottom, top)
+	}
+}
+
 func BenchmarkMatrix4Equal1(b *testing.B) {
 	m := NewMatrix4Identity()
 	for n := 0; n < b.N; n++ {
@@ -0,0 +1,185 @@
+package goglmath
+
+import (
+	"errors"
+)
+
+// Matrix3 is a 3x3 matrix.
+// Matrix3 is stored in column-major order, just like Matrix4.
+type Matrix3 struct {
+	data [9]float32
+}
+
+var mat3identity = Matrix3{[9]float32{
+	1, 0, 0,
+	0, 1, 0,
+	0, 0, 1,
+}}
+
+// NewMatrix3Identity creates an identity matrix.
+func NewMatrix3Identity() Matrix3 {
+	return mat3identity // clone -- it's unsafe to return pointer to the original data
+}
+
+// Data returns matrix data as slice ready to GPU upload.
+func (m *Matrix3) Data() []float32 {
+	return m.data[:]
+}
+
+// Matrix3Equal tests matrices for equality.
+func Matrix3Equal(m1, m2 *Matrix3) bool {
+	// Array values are comparable if values of the array element type are comparable. Two array values are equal if their corresponding elements are equal.
+	return m1.data == m2.data
+}
+
+// Identity reports if matrix is identity.
+func (m *Matrix3) Identity() bool {
+	return m.data == mat3identity.data
+}
+
+// SetIdentity sets matrix to identity.
+func (m *Matrix3) SetIdentity() {
+	m.data = mat3identity.data
+}
+
+// CopyFrom copy matrix data from another source matrix.
+func (m *Matrix3) CopyFrom(src *Matrix3) {
+	m.data = src.data
+}
+
+// CopyFromMatrix4 copy the upper 3x3 of the source matrix.
+func (m *Matrix3) CopyFromMatrix4(src *Matrix4) {
+	m.data[0] = src.data[0]
+	m.data[1] = src.data[1]
+	m.data[2] = src.data[2]
+	m.data[3] = src.data[4]
+	m.data[4] = src.data[5]
+	m.data[5] = src.data[6]
+	m.data[6] = src.data[8]
+	m.data[7] = src.data[9]
+	m.data[8] = src.data[10]
+}
+
+// Transpose transposes the matrix.
+func (m *Matrix3) Transpose() {
+	m.data[1], m.data[3] = m.data[3], m.data[1]
+	m.data[2], m.data[6] = m.data[6], m.data[2]
+	m.data[5], m.data[7] = m.data[7], m.data[5]
+}
+
+// Determinant calculates the determinant of the matrix.
+func (m *Matrix3) Determinant() float64 {
+	a00 := float64(m.data[0])
+	a01 := float64(m.data[1])
+	a02 := float64(m.data[2])
+	a10 := float64(m.data[3])
+	a11 := float64(m.data[4])
+	a12 := float64(m.data[5])
+	a20 := float64(m.data[6])
+	a21 := float64(m.data[7])
+	a22 := float64(m.data[8])
+
+	return a00*(a11*a22-a12*a21) - a01*(a10*a22-a12*a20) + a02*(a10*a21-a11*a20)
+}
+
+// Invert inverts the matrix.
+func (m *Matrix3) Invert() error {
+	return m.CopyInverseFrom(m)
+}
+
+// CopyInverseFrom sets the matrix as inverse of another source matrix.
+func (m *Matrix3) CopyInverseFrom(src *Matrix3) error {
+	a00 := float64(src.data[0])
+	a01 := float64(src.data[1])
+	a02 := float64(src.data[2])
+	a10 := float64(src.data[3])
+	a11 := float64(src.data[4])
+	a12 := float64(src.data[5])
+	a20 := float64(src.data[6])
+	a21 := float64(src.data[7])
+	a22 := float64(src.data[8])
+
+	b01 := a22*a11 - a12*a21
+	b11 := -a22*a10 + a12*a20
+	b21 := a21*a10 - a11*a20
+
+	det := a00*b01 + a01*b11 + a02*b21
+	if det == 0.0 {
+		m.CopyFrom(src)
+		return errors.New("copyInverseFrom: null determinant")
+	}
+	invDet := 1.0 / det
+
+	m.data[0] = float32(b01 * invDet)
+	m.data[1] = float32((-a22*a01 + a02*a21) * invDet)
+	m.data[2] = float32((a12*a01 - a02*a11) * invDet)
+	m.data[3] = float32(b11 * invDet)
+	m.data[4] = float32((a22*a00 - a02*a20) * invDet)
+	m.data[5] = float32((-a12*a00 + a02*a10) * invDet)
+	m.data[6] = float32(b21 * invDet)
+	m.data[7] = float32((-a21*a00 + a01*a20) * invDet)
+	m.data[8] = float32((a11*a00 - a01*a10) * invDet)
+
+	return nil
+}
+
+// Multiply multiplies the matrix by another matrix.
+func (m *Matrix3) Multiply(n *Matrix3) {
+	m00 := m.data[0]
+	m01 := m.data[3]
+	m02 := m.data[6]
+	m10 := m.data[1]
+	m11 := m.data[4]
+	m12 := m.data[7]
+	m20 := m.data[2]
+	m21 := m.data[5]
+	m22 := m.data[8]
+
+	n00 := n.data[0]
+	n01 := n.data[3]
+	n02 := n.data[6]
+	n10 := n.data[1]
+	n11 := n.data[4]
+	n12 := n.data[7]
+	n20 := n.data[2]
+	n21 := n.data[5]
+	n22 := n.data[8]
+
+	m.data[0] = (m00 * n00) + (m01 * n10) + (m02 * n20)
+	m.data[3] = (m00 * n01) + (m01 * n11) + (m02 * n21)
+	m.data[6] = (m00 * n02) + (m01 * n12) + (m02 * n22)
+	m.data[1] = (m10 * n00) + (m11 * n10) + (m12 * n20)
+	m.data[4] = (m10 * n01) + (m11 * n11) + (m12 * n21)
+	m.data[7] = (m10 * n02) + (m11 * n12) + (m12 * n22)
+	m.data[2] = (m20 * n00) + (m21 * n10) + (m22 * n20)
+	m.data[5] = (m20 * n01) + (m21 * n11) + (m22 * n21)
+	m.data[8] = (m20 * n02) + (m21 * n12) + (m22 * n22)
+}
+
+// Transform multiples this matrix [m] by vector [x,y,z]
+func (m *Matrix3) Transform(x, y, z float64) (tx, ty, tz float64) {
+	tx = float64(m.data[0])*x + float64(m.data[3])*y + float64(m.data[6])*z
+	ty = float64(m.data[1])*x + float64(m.data[4])*y + float64(m.data[7])*z
+	tz = float64(m.data[2])*x + float64(m.data[5])*y + float64(m.data[8])*z
+	return
+}
+
+// TransformVector3 multiplies this matrix [m] by vector v.
+func (m *Matrix3) TransformVector3(v Vector3) Vector3 {
+	x, y, z := m.Transform(v.X, v.Y, v.Z)
+	return Vector3{x, y, z}
+}
+
+// NormalMatrix sets normalMatrix as the inverse-transpose of the upper 3x3 of the matrix.
+// The normal matrix transforms surface normals correctly even under non-uniform scaling.
+// Usually m is the model-view matrix.
+// If the upper 3x3 is singular, normalMatrix receives the upper 3x3 unchanged and an error is returned.
+func (m *Matrix4) NormalMatrix(normalMatrix *Matrix3) error {
+	var upper Matrix3
+	upper.CopyFromMatrix4(m)
+	if err := normalMatrix.CopyInverseFrom(&upper); err != nil {
+		return err
+	}
+	normalMatrix.Transpose()
+	return nil
+}
@@ -0,0 +1,78 @@
+package goglmath
+
+import (
+	"testing"
+)
+
+func TestMatrix3Invert(t *testing.T) {
+	m := Matrix3{[9]float32{
+		2, 0, 0,
+		0, 4, 0,
+		1, 2, 1,
+	}}
+	if det := m.Determinant(); det != 8 {
+		t.Errorf("determinant: expected=8 got=%v", det)
+	}
+	var inv Matrix3
+	if err := inv.CopyInverseFrom(&m); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	inv.Multiply(&m)
+	if !inv.Identity() {
+		t.Errorf("inverse times matrix is not identity: %v", inv)
+	}
+}
+
+func TestMatrix3Singular(t *testing.T) {
+	m := Matrix3{[9]float32{
+		1, 2, 3,
+		2, 4, 6,
+		0, 0, 1,
+	}}
+	orig := m
+	if err := m.Invert(); err == nil {
+		t.Errorf("singular matrix inverted without error")
+	}
+	if !Matrix3Equal(&m, &orig) {
+		t.Errorf("singular matrix modified: %v", m)
+	}
+}
+
+func TestMatrix3Transpose(t *testing.T) {
+	m := Matrix3{[9]float32{0, 1, 2, 3, 4, 5, 6, 7, 8}}
+	m.Transpose()
+	want := Matrix3{[9]float32{0, 3, 6, 1, 4, 7, 2, 5, 8}}
+	if !Matrix3Equal(&m, &want) {
+		t.Errorf("expected=%v got=%v", want, m)
+	}
+}
+
+func TestNormalMatrix(t *testing.T) {
+	modelView := NewMatrix4Identity()
+	modelView.Translate(5, 6, 7, 1)
+	modelView.Scale(2, 1, 1, 1) // non-uniform scale
+
+	var normal Matrix3
+	if err := modelView.NormalMatrix(&normal); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// surface x=y has normal (1,-1,0) and tangent (1,1,0)
+	tangent := modelView.TransformDirection(Vector3{1, 1, 0})
+	n := normal.TransformVector3(Vector3{1, -1, 0})
+	if !tangent.Ortho(n) {
+		t.Errorf("transformed normal %v not orthogonal to transformed tangent %v", n, tangent)
+	}
+
+	var singular Matrix4
+	if err := singular.NormalMatrix(&normal); err == nil {
+		t.Errorf("singular matrix produced normal matrix without error")
+	}
+}
+
+func BenchmarkMatrix3Invert(b *testing.B) {
+	m := NewMatrix3Identity()
+	for n := 0; n < b.N; n++ {
+		m.Invert()
+	}
+}
@@ -0,0 +1,49 @@
+//go:build amd64 && !purego
+
+package goglmath
+
+// SSE2 and AVX implementations, see matrix4_amd64.s.
+// SSE2 is part of the amd64 baseline; AVX is detected at startup.
+// Operations are performed in the same order as the generic code, without FMA,
+// so results are bit-for-bit identical.
+
+// useAVX reports if the CPU and OS support AVX.
+var useAVX = hasAVX()
+
+func hasAVX() bool
+
+func multiply4(dst, a, b *[16]float32) {
+	if useAVX {
+		multiply4AVX(dst, a, b)
+		return
+	}
+	multiply4SSE2(dst, a, b)
+}
+
+// inverse4 has no AVX version: the cofactor computation is made of 4-wide shuffles
+// that gain nothing from 8-wide registers.
+func inverse4(dst, src *[16]float32) float32 {
+	return inverse4SSE2(dst, src)
+}
+
+func transform4(m *[16]float32, x, y, z, w float64) (tx, ty, tz, tw float64) {
+	if useAVX {
+		return transform4AVX(m, x, y, z, w)
+	}
+	return transform4SSE2(m, x, y, z, w)
+}
+
+//go:noescape
+func multiply4SSE2(dst, a, b *[16]float32)
+
+//go:noescape
+func multiply4AVX(dst, a, b *[16]float32)
+
+//go:noescape
+func inverse4SSE2(dst, src *[16]float32) float32
+
+//go:noescape
+func transform4SSE2(m *[16]float32, x, y, z, w float64) (tx, ty, tz, tw float64)
+
+//go:noescape
+func transform4AVX(m *[16]float32, x, y, z, w float64) (tx, ty, tz, tw float64)
@@ -0,0 +1,327 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// Matrices are column-major: X0..X3 hold columns 0..3.
+
+// sign masks for negating lanes: + - + - and - + - +
+DATA signPNPN<>+0(SB)/4, $0x00000000
+DATA signPNPN<>+4(SB)/4, $0x80000000
+DATA signPNPN<>+8(SB)/4, $0x00000000
+DATA signPNPN<>+12(SB)/4, $0x80000000
+GLOBL signPNPN<>(SB), RODATA|NOPTR, $16
+
+DATA signNPNP<>+0(SB)/4, $0x80000000
+DATA signNPNP<>+4(SB)/4, $0x00000000
+DATA signNPNP<>+8(SB)/4, $0x80000000
+DATA signNPNP<>+12(SB)/4, $0x00000000
+GLOBL signNPNP<>(SB), RODATA|NOPTR, $16
+
+// MULCOL computes column off/16 of a*b into dst:
+// dst = a.col0*b[off] + a.col1*b[off+1] + a.col2*b[off+2] + a.col3*b[off+3]
+#define MULCOL(off, dst) \
+	MOVSS  off+0(DX), dst        \
+	SHUFPS $0x00, dst, dst       \
+	MULPS  X0, dst               \
+	MOVSS  off+4(DX), X12        \
+	SHUFPS $0x00, X12, X12       \
+	MULPS  X1, X12               \
+	ADDPS  X12, dst              \
+	MOVSS  off+8(DX), X12        \
+	SHUFPS $0x00, X12, X12       \
+	MULPS  X2, X12               \
+	ADDPS  X12, dst              \
+	MOVSS  off+12(DX), X12       \
+	SHUFPS $0x00, X12, X12       \
+	MULPS  X3, X12               \
+	ADDPS  X12, dst
+
+// func multiply4SSE2(dst, a, b *[16]float32)
+TEXT ·multiply4SSE2(SB), NOSPLIT, $0-24
+	MOVQ dst+0(FP), DI
+	MOVQ a+8(FP), SI
+	MOVQ b+16(FP), DX
+
+	MOVUPS 0(SI), X0
+	MOVUPS 16(SI), X1
+	MOVUPS 32(SI), X2
+	MOVUPS 48(SI), X3
+
+	// all columns are computed before storing, since dst may alias a or b
+	MULCOL(0, X8)
+	MULCOL(16, X9)
+	MULCOL(32, X10)
+	MULCOL(48, X11)
+
+	MOVUPS X8, 0(DI)
+	MOVUPS X9, 16(DI)
+	MOVUPS X10, 32(DI)
+	MOVUPS X11, 48(DI)
+	RET
+
+// MINORS computes 2x2 minors from columns p and q:
+// lo = [p0 p0 p0 p1]*[q1 q2 q3 q2] - [p1 p2 p3 p2]*[q0 q0 q0 q1]
+// hi = [p1 p2 p1 p2]*[q3 q3 q3 q3] - [p3 p3 p3 p3]*[q1 q2 q1 q2]
+#define MINORS(p, q, lo, hi) \
+	PSHUFD $0x40, p, lo   \
+	PSHUFD $0xB9, q, X12  \
+	MULPS  X12, lo        \
+	PSHUFD $0xB9, p, X12  \
+	PSHUFD $0x40, q, X13  \
+	MULPS  X13, X12       \
+	SUBPS  X12, lo        \
+	PSHUFD $0x99, p, hi   \
+	PSHUFD $0xFF, q, X12  \
+	MULPS  X12, hi        \
+	PSHUFD $0xFF, p, X12  \
+	PSHUFD $0x99, q, X13  \
+	MULPS  X13, X12       \
+	SUBPS  X12, hi
+
+// COFACTOR computes dst = (s1*k1 ^ m1) + (s2*k2 ^ m2) + (s3*k3 ^ m1)
+// Subtraction is performed as addition of the negated product, which is exact in IEEE 754.
+#define COFACTOR(s1, k1, s2, k2, s3, k3, m1, m2, dst) \
+	MOVAPS s1, dst  \
+	MULPS  k1, dst  \
+	XORPS  m1, dst  \
+	MOVAPS s2, X6   \
+	MULPS  k2, X6   \
+	XORPS  m2, X6   \
+	ADDPS  X6, dst  \
+	MOVAPS s3, X6   \
+	MULPS  k3, X6   \
+	XORPS  m1, X6   \
+	ADDPS  X6, dst  \
+	MULPS  X11, dst
+
+// func inverse4SSE2(dst, src *[16]float32) float32
+TEXT ·inverse4SSE2(SB), NOSPLIT, $0-20
+	MOVQ dst+0(FP), DI
+	MOVQ src+8(FP), SI
+
+	MOVUPS 0(SI), X0
+	MOVUPS 16(SI), X1
+	MOVUPS 32(SI), X2
+	MOVUPS 48(SI), X3
+
+	// X4 = b00 b01 b02 b03, X5 = b04 b05 b04 b05
+	MINORS(X0, X1, X4, X5)
+
+	// X6 = b06 b07 b08 b09, X7 = b10 b11 b10 b11
+	MINORS(X2, X3, X6, X7)
+
+	// det = b00*b11 - b01*b10 + b02*b09 + b03*b08 - b04*b07 + b05*b06
+	MOVAPS X7, X8
+	SHUFPS $0xB1, X6, X8 // b11 b10 b09 b08
+	MULPS  X4, X8
+	PSHUFD $0x01, X6, X9 // b07 b06
+	MULPS  X5, X9
+	MOVAPS X8, X10
+	PSHUFD $0x01, X8, X11
+	SUBSS  X11, X10
+	PSHUFD $0x02, X8, X11
+	ADDSS  X11, X10
+	PSHUFD $0x03, X8, X11
+	ADDSS  X11, X10
+	SUBSS  X9, X10
+	PSHUFD $0x01, X9, X11
+	ADDSS  X11, X10
+
+	MOVSS   X10, ret+16(FP)
+	XORPS   X11, X11
+	UCOMISS X11, X10
+	JNE     invertible
+	JP      invertible // NaN is not zero
+	RET
+
+invertible:
+	// X11 = invDet broadcast
+	MOVL   $0x3f800000, AX // 1.0
+	MOVQ   AX, X11
+	DIVSS  X10, X11
+	SHUFPS $0x00, X11, X11
+
+	// X12..X15 = [b06 b06 b00 b00] [b07 b07 b01 b01] [b08 b08 b02 b02] [b09 b09 b03 b03]
+	MOVAPS X6, X12
+	SHUFPS $0x00, X4, X12
+	MOVAPS X6, X13
+	SHUFPS $0x55, X4, X13
+	MOVAPS X6, X14
+	SHUFPS $0xAA, X4, X14
+	MOVAPS X6, X15
+	SHUFPS $0xFF, X4, X15
+
+	// X8, X9 = [b10 b10 b04 b04] [b11 b11 b05 b05]
+	MOVAPS X7, X8
+	SHUFPS $0x00, X5, X8
+	MOVAPS X7, X9
+	SHUFPS $0x55, X5, X9
+
+	// transpose-like shuffle of columns, a{col}{row}:
+	// X0 = a10 a00 a30 a20
+	// X1 = a11 a01 a31 a21
+	// X2 = a12 a02 a32 a22
+	// X3 = a13 a03 a33 a23
+	MOVAPS   X1, X4
+	UNPCKLPS X0, X4 // a10 a00 a11 a01
+	MOVAPS   X3, X5
+	UNPCKLPS X2, X5 // a30 a20 a31 a21
+	MOVAPS   X1, X6
+	UNPCKHPS X0, X6 // a12 a02 a13 a03
+	MOVAPS   X3, X7
+	UNPCKHPS X2, X7 // a32 a22 a33 a23
+	MOVAPS   X4, X0
+	MOVLHPS  X5, X0
+	MOVAPS   X5, X1
+	MOVHLPS  X4, X1
+	MOVAPS   X6, X2
+	MOVLHPS  X7, X2
+	MOVAPS   X7, X3
+	MOVHLPS  X6, X3
+
+	MOVUPS signPNPN<>(SB), X4
+	MOVUPS signNPNP<>(SB), X5
+
+	COFACTOR(X1, X9, X2, X8, X3, X15, X4, X5, X7)
+	MOVUPS X7, 0(DI)
+	COFACTOR(X0, X9, X2, X14, X3, X13, X5, X4, X7)
+	MOVUPS X7, 16(DI)
+	COFACTOR(X0, X8, X1, X14, X3, X12, X4, X5, X7)
+	MOVUPS X7, 32(DI)
+	COFACTOR(X0, X15, X1, X13, X2, X12, X5, X4, X7)
+	MOVUPS X7, 48(DI)
+	RET
+
+// func transform4SSE2(m *[16]float32, x, y, z, w float64) (tx, ty, tz, tw float64)
+TEXT ·transform4SSE2(SB), NOSPLIT, $0-72
+	MOVQ m+0(FP), AX
+
+	// X0 = tx ty, X1 = tz tw
+	MOVSD    x+8(FP), X4
+	UNPCKLPD X4, X4
+	CVTPS2PD 0(AX), X0
+	MULPD    X4, X0
+	CVTPS2PD 8(AX), X1
+	MULPD    X4, X1
+
+	MOVSD    y+16(FP), X4
+	UNPCKLPD X4, X4
+	CVTPS2PD 16(AX), X2
+	MULPD    X4, X2
+	ADDPD    X2, X0
+	CVTPS2PD 24(AX), X3
+	MULPD    X4, X3
+	ADDPD    X3, X1
+
+	MOVSD    z+24(FP), X4
+	UNPCKLPD X4, X4
+	CVTPS2PD 32(AX), X2
+	MULPD    X4, X2
+	ADDPD    X2, X0
+	CVTPS2PD 40(AX), X3
+	MULPD    X4, X3
+	ADDPD    X3, X1
+
+	MOVSD    w+32(FP), X4
+	UNPCKLPD X4, X4
+	CVTPS2PD 48(AX), X2
+	MULPD    X4, X2
+	ADDPD    X2, X0
+	CVTPS2PD 56(AX), X3
+	MULPD    X4, X3
+	ADDPD    X3, X1
+
+	MOVSD  X0, tx+40(FP)
+	MOVHPD X0, ty+48(FP)
+	MOVSD  X1, tz+56(FP)
+	MOVHPD X1, tw+64(FP)
+	RET
+
+// func hasAVX() bool
+TEXT ·hasAVX(SB), NOSPLIT, $0-1
+	// CPUID.1:ECX: OSXSAVE (bit 27) and AVX (bit 28)
+	MOVL $1, AX
+	XORL CX, CX
+	CPUID
+	ANDL $0x18000000, CX
+	CMPL CX, $0x18000000
+	JNE  noavx
+
+	// XCR0: OS saves XMM (bit 1) and YMM (bit 2) state
+	XORL CX, CX
+	XGETBV
+	ANDL $6, AX
+	CMPL AX, $6
+	JNE  noavx
+	MOVB $1, ret+0(FP)
+	RET
+
+noavx:
+	MOVB $0, ret+0(FP)
+	RET
+
+// MULCOL2 computes two columns of a*b into dst, with Y0..Y3 holding columns of a in both lanes
+// and src holding the two columns of b.
+#define MULCOL2(src, dst) \
+	VPERMILPS $0x00, src, dst \
+	VMULPS    Y0, dst, dst    \
+	VPERMILPS $0x55, src, Y6  \
+	VMULPS    Y1, Y6, Y6      \
+	VADDPS    Y6, dst, dst    \
+	VPERMILPS $0xAA, src, Y6  \
+	VMULPS    Y2, Y6, Y6      \
+	VADDPS    Y6, dst, dst    \
+	VPERMILPS $0xFF, src, Y6  \
+	VMULPS    Y3, Y6, Y6      \
+	VADDPS    Y6, dst, dst
+
+// func multiply4AVX(dst, a, b *[16]float32)
+TEXT ·multiply4AVX(SB), NOSPLIT, $0-24
+	MOVQ dst+0(FP), DI
+	MOVQ a+8(FP), SI
+	MOVQ b+16(FP), DX
+
+	VBROADCASTF128 0(SI), Y0
+	VBROADCASTF128 16(SI), Y1
+	VBROADCASTF128 32(SI), Y2
+	VBROADCASTF128 48(SI), Y3
+
+	// all columns are loaded before storing, since dst may alias a or b
+	VMOVUPS 0(DX), Y4
+	VMOVUPS 32(DX), Y5
+
+	MULCOL2(Y4, Y7)
+	MULCOL2(Y5, Y8)
+
+	VMOVUPS Y7, 0(DI)
+	VMOVUPS Y8, 32(DI)
+	VZEROUPPER
+	RET
+
+// func transform4AVX(m *[16]float32, x, y, z, w float64) (tx, ty, tz, tw float64)
+TEXT ·transform4AVX(SB), NOSPLIT, $0-72
+	MOVQ m+0(FP), AX
+
+	// Y0 = tx ty tz tw
+	VCVTPS2PD    0(AX), Y0
+	VBROADCASTSD x+8(FP), Y4
+	VMULPD       Y4, Y0, Y0
+
+	VCVTPS2PD    16(AX), Y1
+	VBROADCASTSD y+16(FP), Y4
+	VMULPD       Y4, Y1, Y1
+	VADDPD       Y1, Y0, Y0
+
+	VCVTPS2PD    32(AX), Y1
+	VBROADCASTSD z+24(FP), Y4
+	VMULPD       Y4, Y1, Y1
+	VADDPD       Y1, Y0, Y0
+
+	VCVTPS2PD    48(AX), Y1
+	VBROADCASTSD w+32(FP), Y4
+	VMULPD       Y4, Y1, Y1
+	VADDPD       Y1, Y0, Y0
+
+	VMOVUPD Y0, tx+40(FP)
+	VZEROUPPER
+	RET
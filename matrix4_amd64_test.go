@@ -0,0 +1,70 @@
+//go:build amd64 && !purego
+
+package goglmath
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// The generic tests exercise the implementation selected for this CPU;
+// these check the SSE2 and AVX versions separately.
+
+func TestMultiply4Kernels(t *testing.T) {
+	kernels := map[string]func(dst, a, b *[16]float32){"SSE2": multiply4SSE2}
+	if useAVX {
+		kernels["AVX"] = multiply4AVX
+	}
+	list := testMatrices()
+	for name, multiply := range kernels {
+		for i := 1; i < len(list); i++ {
+			a, b := list[i-1], list[i]
+			var got, want [16]float32
+			multiply(&got, &a, &b)
+			multiply4Generic(&want, &a, &b)
+			if !bitsEqual(&got, &want) {
+				t.Fatalf("%s multiply %d: expected=%v got=%v", name, i, want, got)
+			}
+			got = b
+			multiply(&got, &a, &got)
+			if !bitsEqual(&got, &want) {
+				t.Fatalf("%s multiply %d aliased: expected=%v got=%v", name, i, want, got)
+			}
+		}
+	}
+}
+
+func TestTransform4Kernels(t *testing.T) {
+	kernels := map[string]func(m *[16]float32, x, y, z, w float64) (float64, float64, float64, float64){"SSE2": transform4SSE2}
+	if useAVX {
+		kernels["AVX"] = transform4AVX
+	}
+	for name, transform := range kernels {
+		r := rand.New(rand.NewSource(2))
+		for i, m := range testMatrices() {
+			x, y, z, w := r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64()
+			gx, gy, gz, gw := transform(&m, x, y, z, w)
+			wx, wy, wz, ww := transform4Generic(&m, x, y, z, w)
+			if gx != wx || gy != wy || gz != wz || gw != ww {
+				t.Fatalf("%s transform %d: expected=%v,%v,%v,%v got=%v,%v,%v,%v", name, i, wx, wy, wz, ww, gx, gy, gz, gw)
+			}
+		}
+	}
+}
+
+func BenchmarkMultiplySSE2(b *testing.B) {
+	list := testMatrices()
+	a, n := list[0], list[1]
+	var m [16]float32
+	for i := 0; i < b.N; i++ {
+		multiply4SSE2(&m, &a, &n)
+	}
+}
+
+func BenchmarkTransformSSE2(b *testing.B) {
+	list := testMatrices()
+	m := list[0]
+	for i := 0; i < b.N; i++ {
+		transform4SSE2(&m, 1, 2, 3, 1)
+	}
+}
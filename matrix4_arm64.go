@@ -0,0 +1,19 @@
+//go:build arm64 && goglmath_neon && !purego
+
+package goglmath
+
+// NEON implementations, see matrix4_arm64.s.
+// They are enabled by the goglmath_neon build tag, until they are tested on arm64 hardware in CI;
+// by default arm64 uses the generic code.
+// NEON is part of the arm64 baseline, hence no CPU feature detection is needed.
+// Operations are performed in the same order as the generic code, without fused multiply-add,
+// so results are bit-for-bit identical.
+
+//go:noescape
+func multiply4(dst, a, b *[16]float32)
+
+//go:noescape
+func inverse4(dst, src *[16]float32) float32
+
+//go:noescape
+func transform4(m *[16]float32, x, y, z, w float64) (tx, ty, tz, tw float64)
@@ -0,0 +1,253 @@
+//go:build arm64 && goglmath_neon && !purego
+
+#include "textflag.h"
+
+// Matrices are column-major: V0..V3 hold columns 0..3.
+//
+// Floating-point vector arithmetic is encoded with WORD, since older assemblers lack these instructions.
+// Macro arguments are register numbers, hence TBL and DUP are encoded with WORD as well.
+// FMLA is never used: fused multiply-add would round differently from the generic code.
+
+// FMUL Vd.4S, Vn.4S, Vm.4S
+#define FMUL4S(m, n, d) WORD $(0x6E20DC00 | (m)<<16 | (n)<<5 | (d))
+
+// FMUL Vd.4S, Vn.4S, Vm.S[i]
+#define FMULE4S(i, m, n, d) WORD $(0x4F809000 | ((i)&1)<<21 | (m)<<16 | ((i)>>1)<<11 | (n)<<5 | (d))
+
+// FADD Vd.4S, Vn.4S, Vm.4S
+#define FADD4S(m, n, d) WORD $(0x4E20D400 | (m)<<16 | (n)<<5 | (d))
+
+// FSUB Vd.4S, Vn.4S, Vm.4S
+#define FSUB4S(m, n, d) WORD $(0x4EA0D400 | (m)<<16 | (n)<<5 | (d))
+
+// FMUL Vd.2D, Vn.2D, Vm.D[i]
+#define FMULE2D(i, m, n, d) WORD $(0x4FC09000 | (m)<<16 | (i)<<11 | (n)<<5 | (d))
+
+// FADD Vd.2D, Vn.2D, Vm.2D
+#define FADD2D(m, n, d) WORD $(0x4E60D400 | (m)<<16 | (n)<<5 | (d))
+
+// FCVTL Vd.2D, Vn.2S (lower half) and FCVTL2 Vd.2D, Vn.4S (upper half)
+#define FCVTL(n, d) WORD $(0x0E617800 | (n)<<5 | (d))
+#define FCVTL2(n, d) WORD $(0x4E617800 | (n)<<5 | (d))
+
+// TBL Vd.16B, [Vn.16B], Vm.16B
+#define TBL(m, n, d) WORD $(0x4E000000 | (m)<<16 | (n)<<5 | (d))
+
+// DUP Vd.4S, Vn.S[i]
+#define DUP4S(i, n, d) WORD $(0x4E040400 | (i)<<19 | (n)<<5 | (d))
+
+// EOR Vd.16B, Vn.16B, Vm.16B
+#define EOR16B(m, n, d) WORD $(0x6E201C00 | (m)<<16 | (n)<<5 | (d))
+
+// TBL indices selecting lanes 0 0 0 1, 1 2 3 2 and 1 2 1 2
+DATA shuffles<>+0(SB)/4, $0x03020100
+DATA shuffles<>+4(SB)/4, $0x03020100
+DATA shuffles<>+8(SB)/4, $0x03020100
+DATA shuffles<>+12(SB)/4, $0x07060504
+DATA shuffles<>+16(SB)/4, $0x07060504
+DATA shuffles<>+20(SB)/4, $0x0B0A0908
+DATA shuffles<>+24(SB)/4, $0x0F0E0D0C
+DATA shuffles<>+28(SB)/4, $0x0B0A0908
+DATA shuffles<>+32(SB)/4, $0x07060504
+DATA shuffles<>+36(SB)/4, $0x0B0A0908
+DATA shuffles<>+40(SB)/4, $0x07060504
+DATA shuffles<>+44(SB)/4, $0x0B0A0908
+GLOBL shuffles<>(SB), RODATA|NOPTR, $48
+
+// sign masks for negating lanes: + - + - and - + - +
+DATA signs<>+0(SB)/4, $0x00000000
+DATA signs<>+4(SB)/4, $0x80000000
+DATA signs<>+8(SB)/4, $0x00000000
+DATA signs<>+12(SB)/4, $0x80000000
+DATA signs<>+16(SB)/4, $0x80000000
+DATA signs<>+20(SB)/4, $0x00000000
+DATA signs<>+24(SB)/4, $0x80000000
+DATA signs<>+28(SB)/4, $0x00000000
+GLOBL signs<>(SB), RODATA|NOPTR, $32
+
+// MULCOL computes column b-4 of a*b into d:
+// d = a.col0*b[0] + a.col1*b[1] + a.col2*b[2] + a.col3*b[3]
+#define MULCOL(b, d) \
+	FMULE4S(0, b, 0, d)      /* FMUL Vd.4S, V0.4S, Vb.S[0] */ \
+	FMULE4S(1, b, 1, 20)     /* FMUL V20.4S, V1.4S, Vb.S[1] */ \
+	FADD4S(20, d, d)         /* FADD Vd.4S, Vd.4S, V20.4S */ \
+	FMULE4S(2, b, 2, 20)     /* FMUL V20.4S, V2.4S, Vb.S[2] */ \
+	FADD4S(20, d, d)         /* FADD Vd.4S, Vd.4S, V20.4S */ \
+	FMULE4S(3, b, 3, 20)     /* FMUL V20.4S, V3.4S, Vb.S[3] */ \
+	FADD4S(20, d, d)         /* FADD Vd.4S, Vd.4S, V20.4S */
+
+// func multiply4(dst, a, b *[16]float32)
+TEXT ·multiply4(SB), NOSPLIT, $0-24
+	MOVD dst+0(FP), R0
+	MOVD a+8(FP), R1
+	MOVD b+16(FP), R2
+
+	VLD1 (R1), [V0.S4, V1.S4, V2.S4, V3.S4]
+	VLD1 (R2), [V4.S4, V5.S4, V6.S4, V7.S4]
+
+	// all columns are computed before storing, since dst may alias a or b
+	MULCOL(4, 16)
+	MULCOL(5, 17)
+	MULCOL(6, 18)
+	MULCOL(7, 19)
+
+	VST1 [V16.S4, V17.S4, V18.S4, V19.S4], (R0)
+	RET
+
+// MINORS computes 2x2 minors from columns p and q, with V28..V30 holding the shuffles:
+// lo = [p0 p0 p0 p1]*[q1 q2 q3 q2] - [p1 p2 p3 p2]*[q0 q0 q0 q1]
+// hi = [p1 p2 p1 p2]*[q3 q3 q3 q3] - [p3 p3 p3 p3]*[q1 q2 q1 q2]
+#define MINORS(p, q, lo, hi) \
+	TBL(28, p, lo)           /* TBL Vlo.16B, [Vp.16B], V28.16B */ \
+	TBL(29, q, 20)           /* TBL V20.16B, [Vq.16B], V29.16B */ \
+	FMUL4S(20, lo, lo)       /* FMUL Vlo.4S, Vlo.4S, V20.4S */ \
+	TBL(29, p, 20)           /* TBL V20.16B, [Vp.16B], V29.16B */ \
+	TBL(28, q, 21)           /* TBL V21.16B, [Vq.16B], V28.16B */ \
+	FMUL4S(21, 20, 20)       /* FMUL V20.4S, V20.4S, V21.4S */ \
+	FSUB4S(20, lo, lo)       /* FSUB Vlo.4S, Vlo.4S, V20.4S */ \
+	TBL(30, p, hi)           /* TBL Vhi.16B, [Vp.16B], V30.16B */ \
+	DUP4S(3, q, 20)          /* DUP V20.4S, Vq.S[3] */ \
+	FMUL4S(20, hi, hi)       /* FMUL Vhi.4S, Vhi.4S, V20.4S */ \
+	DUP4S(3, p, 20)          /* DUP V20.4S, Vp.S[3] */ \
+	TBL(30, q, 21)           /* TBL V21.16B, [Vq.16B], V30.16B */ \
+	FMUL4S(21, 20, 20)       /* FMUL V20.4S, V20.4S, V21.4S */ \
+	FSUB4S(20, hi, hi)       /* FSUB Vhi.4S, Vhi.4S, V20.4S */
+
+// COFACTOR computes d = ((s1*k1 ^ m1) + (s2*k2 ^ m2) + (s3*k3 ^ m1)) * invDet, with V11 holding invDet
+// Subtraction is performed as addition of the negated product, which is exact in IEEE 754.
+#define COFACTOR(s1, k1, s2, k2, s3, k3, m1, m2, d) \
+	FMUL4S(k1, s1, d)        /* FMUL Vd.4S, Vs1.4S, Vk1.4S */ \
+	EOR16B(m1, d, d)         /* EOR Vd.16B, Vd.16B, Vm1.16B */ \
+	FMUL4S(k2, s2, 20)       /* FMUL V20.4S, Vs2.4S, Vk2.4S */ \
+	EOR16B(m2, 20, 20)       /* EOR V20.16B, V20.16B, Vm2.16B */ \
+	FADD4S(20, d, d)         /* FADD Vd.4S, Vd.4S, V20.4S */ \
+	FMUL4S(k3, s3, 20)       /* FMUL V20.4S, Vs3.4S, Vk3.4S */ \
+	EOR16B(m1, 20, 20)       /* EOR V20.16B, V20.16B, Vm1.16B */ \
+	FADD4S(20, d, d)         /* FADD Vd.4S, Vd.4S, V20.4S */ \
+	FMULE4S(0, 11, d, d)     /* FMUL Vd.4S, Vd.4S, V11.S[0] */
+
+// func inverse4(dst, src *[16]float32) float32
+TEXT ·inverse4(SB), NOSPLIT, $0-20
+	MOVD dst+0(FP), R0
+	MOVD src+8(FP), R1
+
+	VLD1 (R1), [V0.S4, V1.S4, V2.S4, V3.S4]
+	MOVD $shuffles<>(SB), R2
+	VLD1 (R2), [V28.B16, V29.B16, V30.B16]
+
+	// V4 = b00 b01 b02 b03, V5 = b04 b05 b04 b05
+	MINORS(0, 1, 4, 5)
+
+	// V6 = b06 b07 b08 b09, V7 = b10 b11 b10 b11
+	MINORS(2, 3, 6, 7)
+
+	// det = b00*b11 - b01*b10 + b02*b09 + b03*b08 - b04*b07 + b05*b06
+	VREV64 V6.S4, V22.S4       // b07 b06 b09 b08
+	VREV64 V7.S4, V23.S4       // b11 b10 b11 b10
+	VMOV   V22.D[1], V23.D[1]  // b11 b10 b09 b08
+	FMUL4S(4, 23, 23)      // FMUL V23.4S, V23.4S, V4.4S
+	FMUL4S(5, 22, 22)      // FMUL V22.4S, V22.4S, V5.4S
+	DUP4S(1, 23, 20)       // DUP V20.4S, V23.S[1]
+	FSUBS  F20, F23, F10
+	DUP4S(2, 23, 20)       // DUP V20.4S, V23.S[2]
+	FADDS  F20, F10, F10
+	DUP4S(3, 23, 20)       // DUP V20.4S, V23.S[3]
+	FADDS  F20, F10, F10
+	FSUBS  F22, F10, F10
+	DUP4S(1, 22, 20)       // DUP V20.4S, V22.S[1]
+	FADDS  F20, F10, F10
+
+	FMOVS F10, ret+16(FP)
+	FCMPS $(0.0), F10
+	BNE   invertible // NaN is not zero
+	RET
+
+invertible:
+	// F11 = invDet
+	FMOVS $1.0, F11
+	FDIVS F10, F11, F11
+
+	// V12..V15 = [b06 b06 b00 b00] [b07 b07 b01 b01] [b08 b08 b02 b02] [b09 b09 b03 b03]
+	VZIP1 V6.S4, V6.S4, V20.S4
+	VZIP1 V4.S4, V4.S4, V21.S4
+	VZIP1 V21.D2, V20.D2, V12.D2
+	VZIP2 V21.D2, V20.D2, V13.D2
+	VZIP2 V6.S4, V6.S4, V20.S4
+	VZIP2 V4.S4, V4.S4, V21.S4
+	VZIP1 V21.D2, V20.D2, V14.D2
+	VZIP2 V21.D2, V20.D2, V15.D2
+
+	// V8, V9 = [b10 b10 b04 b04] [b11 b11 b05 b05]
+	VZIP1 V7.S4, V7.S4, V20.S4
+	VZIP1 V5.S4, V5.S4, V21.S4
+	VZIP1 V21.D2, V20.D2, V8.D2
+	VZIP2 V21.D2, V20.D2, V9.D2
+
+	// transpose-like shuffle of columns, a{col}{row}:
+	// V24 = a10 a00 a30 a20
+	// V25 = a11 a01 a31 a21
+	// V26 = a12 a02 a32 a22
+	// V27 = a13 a03 a33 a23
+	VLD4   (R1), [V24.S4, V25.S4, V26.S4, V27.S4]
+	VREV64 V24.S4, V24.S4
+	VREV64 V25.S4, V25.S4
+	VREV64 V26.S4, V26.S4
+	VREV64 V27.S4, V27.S4
+
+	// V16 = + - + -, V17 = - + - +
+	MOVD $signs<>(SB), R2
+	VLD1 (R2), [V16.B16, V17.B16]
+
+	// all columns are computed before storing, since dst may alias src
+	COFACTOR(25, 9, 26, 8, 27, 15, 16, 17, 0)
+	COFACTOR(24, 9, 26, 14, 27, 13, 17, 16, 1)
+	COFACTOR(24, 8, 25, 14, 27, 12, 16, 17, 2)
+	COFACTOR(24, 15, 25, 13, 26, 12, 17, 16, 3)
+
+	VST1 [V0.S4, V1.S4, V2.S4, V3.S4], (R0)
+	RET
+
+// func transform4(m *[16]float32, x, y, z, w float64) (tx, ty, tz, tw float64)
+TEXT ·transform4(SB), NOSPLIT, $0-72
+	MOVD m+0(FP), R0
+
+	VLD1  (R0), [V0.S4, V1.S4, V2.S4, V3.S4]
+	FMOVD x+8(FP), F4
+	FMOVD y+16(FP), F5
+	FMOVD z+24(FP), F6
+	FMOVD w+32(FP), F7
+
+	// V16 = tx ty, V17 = tz tw
+	FCVTL(0, 18)           // FCVTL V18.2D, V0.2S
+	FCVTL2(0, 19)          // FCVTL2 V19.2D, V0.4S
+	FMULE2D(0, 4, 18, 16)  // FMUL V16.2D, V18.2D, V4.D[0]
+	FMULE2D(0, 4, 19, 17)  // FMUL V17.2D, V19.2D, V4.D[0]
+
+	FCVTL(1, 18)           // FCVTL V18.2D, V1.2S
+	FCVTL2(1, 19)          // FCVTL2 V19.2D, V1.4S
+	FMULE2D(0, 5, 18, 18)  // FMUL V18.2D, V18.2D, V5.D[0]
+	FMULE2D(0, 5, 19, 19)  // FMUL V19.2D, V19.2D, V5.D[0]
+	FADD2D(18, 16, 16)     // FADD V16.2D, V16.2D, V18.2D
+	FADD2D(19, 17, 17)     // FADD V17.2D, V17.2D, V19.2D
+
+	FCVTL(2, 18)           // FCVTL V18.2D, V2.2S
+	FCVTL2(2, 19)          // FCVTL2 V19.2D, V2.4S
+	FMULE2D(0, 6, 18, 18)  // FMUL V18.2D, V18.2D, V6.D[0]
+	FMULE2D(0, 6, 19, 19)  // FMUL V19.2D, V19.2D, V6.D[0]
+	FADD2D(18, 16, 16)     // FADD V16.2D, V16.2D, V18.2D
+	FADD2D(19, 17, 17)     // FADD V17.2D, V17.2D, V19.2D
+
+	FCVTL(3, 18)           // FCVTL V18.2D, V3.2S
+	FCVTL2(3, 19)          // FCVTL2 V19.2D, V3.4S
+	FMULE2D(0, 7, 18, 18)  // FMUL V18.2D, V18.2D, V7.D[0]
+	FMULE2D(0, 7, 19, 19)  // FMUL V19.2D, V19.2D, V7.D[0]
+	FADD2D(18, 16, 16)     // FADD V16.2D, V16.2D, V18.2D
+	FADD2D(19, 17, 17)     // FADD V17.2D, V17.2D, V19.2D
+
+	FMOVD F16, tx+40(FP)
+	VMOV  V16.D[1], R1
+	MOVD  R1, ty+48(FP)
+	FMOVD F17, tz+56(FP)
+	VMOV  V17.D[1], R1
+	MOVD  R1, tw+64(FP)
+	RET
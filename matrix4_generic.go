@@ -0,0 +1,168 @@
+package goglmath
+
+// Pure Go implementations of the hot Matrix4 operations.
+// Architectures without assembly versions use these directly;
+// tests use them as reference for the assembly versions.
+//
+// Every product is explicitly converted (rounded) before being added,
+// which keeps the compiler from fusing multiply-add into FMA instructions
+// (as it does on arm64, or on amd64 with GOAMD64=v3).
+// Results are then the same on every architecture and match the assembly versions bit-for-bit.
+
+// multiply4Generic sets dst = a*b.
+// dst may alias a or b.
+func multiply4Generic(dst, a, b *[16]float32) {
+	m00 := a[0]
+	m01 := a[4]
+	m02 := a[8]
+	m03 := a[12]
+	m10 := a[1]
+	m11 := a[5]
+	m12 := a[9]
+	m13 := a[13]
+	m20 := a[2]
+	m21 := a[6]
+	m22 := a[10]
+	m23 := a[14]
+	m30 := a[3]
+	m31 := a[7]
+	m32 := a[11]
+	m33 := a[15]
+
+	n00 := b[0]
+	n01 := b[4]
+	n02 := b[8]
+	n03 := b[12]
+	n10 := b[1]
+	n11 := b[5]
+	n12 := b[9]
+	n13 := b[13]
+	n20 := b[2]
+	n21 := b[6]
+	n22 := b[10]
+	n23 := b[14]
+	n30 := b[3]
+	n31 := b[7]
+	n32 := b[11]
+	n33 := b[15]
+
+	dst[0] = float32(m00*n00) + float32(m01*n10) + float32(m02*n20) + float32(m03*n30)
+	dst[4] = float32(m00*n01) + float32(m01*n11) + float32(m02*n21) + float32(m03*n31)
+	dst[8] = float32(m00*n02) + float32(m01*n12) + float32(m02*n22) + float32(m03*n32)
+	dst[12] = float32(m00*n03) + float32(m01*n13) + float32(m02*n23) + float32(m03*n33)
+	dst[1] = float32(m10*n00) + float32(m11*n10) + float32(m12*n20) + float32(m13*n30)
+	dst[5] = float32(m10*n01) + float32(m11*n11) + float32(m12*n21) + float32(m13*n31)
+	dst[9] = float32(m10*n02) + float32(m11*n12) + float32(m12*n22) + float32(m13*n32)
+	dst[13] = float32(m10*n03) + float32(m11*n13) + float32(m12*n23) + float32(m13*n33)
+	dst[2] = float32(m20*n00) + float32(m21*n10) + float32(m22*n20) + float32(m23*n30)
+	dst[6] = float32(m20*n01) + float32(m21*n11) + float32(m22*n21) + float32(m23*n31)
+	dst[10] = float32(m20*n02) + float32(m21*n12) + float32(m22*n22) + float32(m23*n32)
+	dst[14] = float32(m20*n03) + float32(m21*n13) + float32(m22*n23) + float32(m23*n33)
+	dst[3] = float32(m30*n00) + float32(m31*n10) + float32(m32*n20) + float32(m33*n30)
+	dst[7] = float32(m30*n01) + float32(m31*n11) + float32(m32*n21) + float32(m33*n31)
+	dst[11] = float32(m30*n02) + float32(m31*n12) + float32(m32*n22) + float32(m33*n32)
+	dst[15] = float32(m30*n03) + float32(m31*n13) + float32(m32*n23) + float32(m33*n33)
+}
+
+// adjugate4 sets dst as the adjugate (transposed cofactor matrix) of src, returning the determinant of src.
+// dst may alias src.
+func adjugate4(dst, src *[16]float32) (det float32) {
+	return adjugate(dst, src)
+}
+
+// adjugate is adjugate4 for both precisions, shared with Mat4.
+func adjugate[T ~float32 | ~float64](dst, src *[16]T) (det T) {
+	a00 := src[0]
+	a01 := src[1]
+	a02 := src[2]
+	a03 := src[3]
+	a10 := src[4]
+	a11 := src[5]
+	a12 := src[6]
+	a13 := src[7]
+	a20 := src[8]
+	a21 := src[9]
+	a22 := src[10]
+	a23 := src[11]
+	a30 := src[12]
+	a31 := src[13]
+	a32 := src[14]
+	a33 := src[15]
+
+	b00 := T(a00*a11) - T(a01*a10)
+	b01 := T(a00*a12) - T(a02*a10)
+	b02 := T(a00*a13) - T(a03*a10)
+	b03 := T(a01*a12) - T(a02*a11)
+	b04 := T(a01*a13) - T(a03*a11)
+	b05 := T(a02*a13) - T(a03*a12)
+	b06 := T(a20*a31) - T(a21*a30)
+	b07 := T(a20*a32) - T(a22*a30)
+	b08 := T(a20*a33) - T(a23*a30)
+	b09 := T(a21*a32) - T(a22*a31)
+	b10 := T(a21*a33) - T(a23*a31)
+	b11 := T(a22*a33) - T(a23*a32)
+
+	det = T(b00*b11) - T(b01*b10) + T(b02*b09) + T(b03*b08) - T(b04*b07) + T(b05*b06)
+
+	dst[0] = T(a11*b11) - T(a12*b10) + T(a13*b09)
+	dst[1] = -T(a01*b11) + T(a02*b10) - T(a03*b09)
+	dst[2] = T(a31*b05) - T(a32*b04) + T(a33*b03)
+	dst[3] = -T(a21*b05) + T(a22*b04) - T(a23*b03)
+	dst[4] = -T(a10*b11) + T(a12*b08) - T(a13*b07)
+	dst[5] = T(a00*b11) - T(a02*b08) + T(a03*b07)
+	dst[6] = -T(a30*b05) + T(a32*b02) - T(a33*b01)
+	dst[7] = T(a20*b05) - T(a22*b02) + T(a23*b01)
+	dst[8] = T(a10*b10) - T(a11*b08) + T(a13*b06)
+	dst[9] = -T(a00*b10) + T(a01*b08) - T(a03*b06)
+	dst[10] = T(a30*b04) - T(a31*b02) + T(a33*b00)
+	dst[11] = -T(a20*b04) + T(a21*b02) - T(a23*b00)
+	dst[12] = -T(a10*b09) + T(a11*b07) - T(a12*b06)
+	dst[13] = T(a00*b09) - T(a01*b07) + T(a02*b06)
+	dst[14] = -T(a30*b03) + T(a31*b01) - T(a32*b00)
+	dst[15] = T(a20*b03) - T(a21*b01) + T(a22*b00)
+
+	return det
+}
+
+// inverse4Generic sets dst as the inverse of src, returning the determinant of src.
+// dst may alias src.
+// If src is singular, dst is left untouched and 0 is returned.
+func inverse4Generic(dst, src *[16]float32) float32 {
+	var adj [16]float32
+	det := adjugate4(&adj, src)
+	if det == 0.0 {
+		return det
+	}
+	invDet := 1.0 / det
+	for i, v := range adj {
+		dst[i] = v * invDet
+	}
+	return det
+}
+
+// transform4Generic multiplies the matrix m by vector [x,y,z,w].
+func transform4Generic(m *[16]float32, x, y, z, w float64) (tx, ty, tz, tw float64) {
+	m0 := float64(m[0])
+	m1 := float64(m[1])
+	m2 := float64(m[2])
+	m3 := float64(m[3])
+	m4 := float64(m[4])
+	m5 := float64(m[5])
+	m6 := float64(m[6])
+	m7 := float64(m[7])
+	m8 := float64(m[8])
+	m9 := float64(m[9])
+	m10 := float64(m[10])
+	m11 := float64(m[11])
+	m12 := float64(m[12])
+	m13 := float64(m[13])
+	m14 := float64(m[14])
+	m15 := float64(m[15])
+
+	tx = float64(m0*x) + float64(m4*y) + float64(m8*z) + float64(m12*w)
+	ty = float64(m1*x) + float64(m5*y) + float64(m9*z) + float64(m13*w)
+	tz = float64(m2*x) + float64(m6*y) + float64(m10*z) + float64(m14*w)
+	tw = float64(m3*x) + float64(m7*y) + float64(m11*z) + float64(m15*w)
+
+	return
+}
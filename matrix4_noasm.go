@@ -0,0 +1,19 @@
+//go:build (!amd64 && !(arm64 && goglmath_neon)) || purego
+
+package goglmath
+
+// Architectures without assembly implementations use the generic code.
+// arm64 uses the generic code unless built with the goglmath_neon tag.
+// The purego build tag forces the generic code on every architecture.
+
+func multiply4(dst, a, b *[16]float32) {
+	multiply4Generic(dst, a, b)
+}
+
+func inverse4(dst, src *[16]float32) float32 {
+	return inverse4Generic(dst, src)
+}
+
+func transform4(m *[16]float32, x, y, z, w float64) (tx, ty, tz, tw float64) {
+	return transform4Generic(m, x, y, z, w)
+}
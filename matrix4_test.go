@@ -0,0 +1,305 @@
+package goglmath
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// testMatrices returns random matrices plus a few with special structure.
+func testMatrices() [][16]float32 {
+	r := rand.New(rand.NewSource(1))
+	var list [][16]float32
+	for i := 0; i < 1000; i++ {
+		var m [16]float32
+		for j := range m {
+			m[j] = float32(r.NormFloat64() * 10)
+		}
+		list = append(list, m)
+	}
+
+	var p, v, model Matrix4
+	SetPerspectiveMatrix(&p, math.Pi/3, 1.5, 0.1, 100)
+	SetViewMatrix(&v, 0, 0, 0, 0, 1, 0, 3, 4, 5)
+	SetModelMatrix(&model, 1, 0, 0, 0, 1, 0, -2, 0, 7)
+	model.Scale(2, 3, 4, 1)
+	list = append(list, mat4identity.data, p.data, v.data, model.data)
+	return list
+}
+
+func bitsEqual(a, b *[16]float32) bool {
+	for i := range a {
+		if math.Float32bits(a[i]) != math.Float32bits(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMultiply4(t *testing.T) {
+	list := testMatrices()
+	for i := 1; i < len(list); i++ {
+		a, b := list[i-1], list[i]
+		var got, want [16]float32
+		multiply4(&got, &a, &b)
+		multiply4Generic(&want, &a, &b)
+		if !bitsEqual(&got, &want) {
+			t.Fatalf("multiply %d: expected=%v got=%v", i, want, got)
+		}
+
+		// aliasing: dst = a, dst = b
+		got = a
+		multiply4(&got, &got, &b)
+		if !bitsEqual(&got, &want) {
+			t.Fatalf("multiply %d aliased: expected=%v got=%v", i, want, got)
+		}
+		got = a
+		multiply4(&got, &got, &got)
+		multiply4Generic(&want, &a, &a)
+		if !bitsEqual(&got, &want) {
+			t.Fatalf("square %d: expected=%v got=%v", i, want, got)
+		}
+	}
+}
+
+func TestInverse4(t *testing.T) {
+	for i, src := range testMatrices() {
+		var got, want [16]float32
+		detGot := inverse4(&got, &src)
+		detWant := inverse4Generic(&want, &src)
+		if math.Float32bits(detGot) != math.Float32bits(detWant) || !bitsEqual(&got, &want) {
+			t.Fatalf("inverse %d: expected=%v,%v got=%v,%v", i, detWant, want, detGot, got)
+		}
+
+		got = src
+		inverse4(&got, &got)
+		if !bitsEqual(&got, &want) {
+			t.Fatalf("inverse %d aliased: expected=%v got=%v", i, want, got)
+		}
+	}
+
+	var null [16]float32
+	dst := mat4identity.data
+	if inverse4(&dst, &null) != 0 {
+		t.Errorf("null matrix reported invertible")
+	}
+	if dst != mat4identity.data {
+		t.Errorf("destination changed for singular matrix: %v", dst)
+	}
+}
+
+func TestTransform4(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i, m := range testMatrices() {
+		x, y, z, w := r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64()
+		gx, gy, gz, gw := transform4(&m, x, y, z, w)
+		wx, wy, wz, ww := transform4Generic(&m, x, y, z, w)
+		if gx != wx || gy != wy || gz != wz || gw != ww {
+			t.Fatalf("transform %d: expected=%v,%v,%v,%v got=%v,%v,%v,%v", i, wx, wy, wz, ww, gx, gy, gz, gw)
+		}
+	}
+}
+
+func BenchmarkMultiply(b *testing.B) {
+	list := testMatrices()
+	a, n := Matrix4{list[0]}, Matrix4{list[1]}
+	for i := 0; i < b.N; i++ {
+		m := a
+		m.Multiply(&n)
+	}
+}
+
+func BenchmarkMultiplyGeneric(b *testing.B) {
+	list := testMatrices()
+	a, n := list[0], list[1]
+	var m [16]float32
+	for i := 0; i < b.N; i++ {
+		multiply4Generic(&m, &a, &n)
+	}
+}
+
+func BenchmarkCopyInverseFrom(b *testing.B) {
+	list := testMatrices()
+	var m Matrix4
+	src := Matrix4{list[0]}
+	for i := 0; i < b.N; i++ {
+		m.CopyInverseFrom(&src)
+	}
+}
+
+func BenchmarkCopyInverseFromGeneric(b *testing.B) {
+	list := testMatrices()
+	var m [16]float32
+	src := list[0]
+	for i := 0; i < b.N; i++ {
+		inverse4Generic(&m, &src)
+	}
+}
+
+func BenchmarkTransform(b *testing.B) {
+	list := testMatrices()
+	m := Matrix4{list[0]}
+	for i := 0; i < b.N; i++ {
+		m.Transform(1, 2, 3, 1)
+	}
+}
+
+func BenchmarkTransformGeneric(b *testing.B) {
+	list := testMatrices()
+	m := list[0]
+	for i := 0; i < b.N; i++ {
+		transform4Generic(&m, 1, 2, 3, 1)
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	m := Matrix4{[16]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}
+	want := Matrix4{[16]float32{1, 5, 9, 13, 2, 6, 10, 14, 3, 7, 11, 15, 4, 8, 12, 16}}
+	var tr Matrix4
+	tr.CopyTransposeFrom(&m)
+	if !Matrix4Equal(&tr, &want) {
+		t.Errorf("copy transpose: expected=%v got=%v", want, tr)
+	}
+	m.Transpose()
+	if !Matrix4Equal(&m, &want) {
+		t.Errorf("transpose: expected=%v got=%v", want, m)
+	}
+	if tr := m.Trace(); tr != 34 {
+		t.Errorf("trace: expected=34 got=%v", tr)
+	}
+}
+
+func TestDeterminant(t *testing.T) {
+	m := NewMatrix4Identity()
+	m.Scale(2, 3, 4, 1)
+	m.Translate(5, 6, 7, 1)
+	if det := m.Determinant(); det != 24 {
+		t.Errorf("determinant: expected=24 got=%v", det)
+	}
+
+	var adj, inv Matrix4
+	adj.CopyAdjugateFrom(&m)
+	inv.CopyInverseFrom(&m)
+	inv.Scale(24, 24, 24, 24)
+	if !matrix4Close(&adj, &inv, 0.0001) {
+		t.Errorf("adjugate: expected=%v got=%v", inv, adj)
+	}
+}
+
+func TestCopyInverseDeterminantFrom(t *testing.T) {
+	for i, data := range testMatrices() {
+		src := Matrix4{data}
+		var got, want Matrix4
+		errWant := want.CopyInverseFrom(&src)
+		det, errGot := got.CopyInverseDeterminantFrom(&src)
+		if (errGot == nil) != (errWant == nil) || !bitsEqual(&got.data, &want.data) {
+			t.Fatalf("inverse %d: expected=%v got=%v", i, want, got)
+		}
+		if det != src.Determinant() {
+			t.Fatalf("determinant %d: expected=%v got=%v", i, src.Determinant(), det)
+		}
+	}
+
+	var null Matrix4
+	if _, err := null.InvertDeterminant(); err == nil {
+		t.Errorf("null matrix: expected error")
+	}
+}
+
+func TestInvertAffineRigid(t *testing.T) {
+	var view, model Matrix4
+	SetViewMatrix(&view, 1, 2, 3, 0, 1, 0, -4, 5, 6)
+	SetModelMatrix(&model, 0, 0.6, -0.8, 1, 0, 0, 7, -8, 9)
+
+	for _, src := range []Matrix4{view, model} {
+		var want, rigid, auto Matrix4
+		if err := want.CopyInverseFrom(&src); err != nil {
+			t.Fatalf("inverse: %v", err)
+		}
+		rigid.CopyRigidInverseFrom(&src)
+		if !matrix4Close(&rigid, &want, 0.00001) {
+			t.Errorf("rigid inverse: expected=%v got=%v", want, rigid)
+		}
+		if !src.IsRigid() {
+			t.Errorf("matrix not detected as rigid: %v", src)
+		}
+		auto = src
+		if err := auto.InvertAuto(); err != nil || !Matrix4Equal(&auto, &rigid) {
+			t.Errorf("auto inverse: expected=%v got=%v", rigid, auto)
+		}
+	}
+
+	scaled := model
+	scaled.Scale(2, 3, 0.5, 1)
+	if scaled.IsRigid() {
+		t.Errorf("scaled matrix detected as rigid")
+	}
+	var want, affine Matrix4
+	want.CopyInverseFrom(&scaled)
+	if err := affine.CopyAffineInverseFrom(&scaled); err != nil {
+		t.Fatalf("affine inverse: %v", err)
+	}
+	if !matrix4Close(&affine, &want, 0.00001) {
+		t.Errorf("affine inverse: expected=%v got=%v", want, affine)
+	}
+	auto := scaled
+	if err := auto.InvertAuto(); err != nil || !Matrix4Equal(&auto, &affine) {
+		t.Errorf("auto inverse: expected=%v got=%v", affine, auto)
+	}
+
+	var proj Matrix4
+	SetPerspectiveMatrix(&proj, math.Pi/2, 1, 1, 10)
+	want.CopyInverseFrom(&proj)
+	auto = proj
+	if err := auto.InvertAuto(); err != nil || !Matrix4Equal(&auto, &want) {
+		t.Errorf("auto inverse of projection: expected=%v got=%v", want, auto)
+	}
+
+	var null Matrix4
+	null.data[15] = 1
+	if err := null.InvertAffine(); err == nil {
+		t.Errorf("singular affine matrix: expected error")
+	}
+}
+
+func TestInvertAutoNearlyAffine(t *testing.T) {
+	var model Matrix4
+	SetModelMatrix(&model, 0, 0.6, -0.8, 1, 0, 0, 7, -8, 9)
+
+	nearlyAffine := model
+	nearlyAffine.data[3] = 0.000002
+	if !nearlyAffine.IsAffine() {
+		t.Fatalf("matrix not classified as affine: %v", nearlyAffine)
+	}
+	var want, auto Matrix4
+	want.CopyInverseFrom(&nearlyAffine)
+	if err := auto.CopyInverseAutoFrom(&nearlyAffine); err != nil || !Matrix4Equal(&auto, &want) {
+		t.Errorf("nearly affine: expected=%v got=%v", want, auto)
+	}
+
+	nearlyRigid := model
+	nearlyRigid.Scale(1.000003, 1, 1, 1)
+	if !nearlyRigid.IsRigid() {
+		t.Fatalf("matrix not classified as rigid: %v", nearlyRigid)
+	}
+	want.CopyAffineInverseFrom(&nearlyRigid)
+	if err := auto.CopyInverseAutoFrom(&nearlyRigid); err != nil || !Matrix4Equal(&auto, &want) {
+		t.Errorf("nearly rigid: expected=%v got=%v", want, auto)
+	}
+}
+
+func BenchmarkInvertRigid(b *testing.B) {
+	var view, m Matrix4
+	SetViewMatrix(&view, 1, 2, 3, 0, 1, 0, -4, 5, 6)
+	for i := 0; i < b.N; i++ {
+		m.CopyRigidInverseFrom(&view)
+	}
+}
+
+func BenchmarkInvertAffine(b *testing.B) {
+	var model, m Matrix4
+	SetModelMatrix(&model, 0, 0.6, -0.8, 1, 0, 0, 7, -8, 9)
+	for i := 0; i < b.N; i++ {
+		m.CopyAffineInverseFrom(&model)
+	}
+}
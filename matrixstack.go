@@ -0,0 +1,110 @@
+package goglmath
+
+import (
+	"errors"
+)
+
+// Matrix stack errors.
+var (
+	ErrMatrixStackOverflow  = errors.New("matrix stack overflow")
+	ErrMatrixStackUnderflow = errors.New("matrix stack underflow")
+)
+
+// MatrixStack is a stack of matrices with glPushMatrix/glPopMatrix semantics.
+// The stack is never empty: it starts with an identity matrix at the top.
+// Operations apply to the top matrix.
+type MatrixStack struct {
+	stack []Matrix4
+	top   int
+	fixed bool
+}
+
+// NewMatrixStack creates a stack that grows as needed.
+func NewMatrixStack() *MatrixStack {
+	return &MatrixStack{stack: []Matrix4{mat4identity}}
+}
+
+// NewMatrixStackFixed creates a stack holding at most maxDepth matrices.
+// The fixed stack is allocated upfront, hence its operations never allocate.
+// Pushing beyond maxDepth reports ErrMatrixStackOverflow.
+func NewMatrixStackFixed(maxDepth int) *MatrixStack {
+	if maxDepth < 1 {
+		maxDepth = 1
+	}
+	s := &MatrixStack{stack: make([]Matrix4, maxDepth), fixed: true}
+	s.stack[0] = mat4identity
+	return s
+}
+
+// Depth reports how many matrices are in the stack.
+func (s *MatrixStack) Depth() int {
+	return s.top + 1
+}
+
+// Push duplicates the top matrix.
+func (s *MatrixStack) Push() error {
+	next := s.top + 1
+	if next == len(s.stack) {
+		if s.fixed {
+			return ErrMatrixStackOverflow
+		}
+		s.stack = append(s.stack, Matrix4{})
+	}
+	s.stack[next] = s.stack[s.top]
+	s.top = next
+	return nil
+}
+
+// Pop discards the top matrix.
+// Popping the last matrix reports ErrMatrixStackUnderflow.
+func (s *MatrixStack) Pop() error {
+	if s.top == 0 {
+		return ErrMatrixStackUnderflow
+	}
+	s.top--
+	return nil
+}
+
+// Top returns the top matrix.
+// The pointer is valid until the next Push or Pop.
+func (s *MatrixStack) Top() *Matrix4 {
+	return &s.stack[s.top]
+}
+
+// Load replaces the top matrix with a copy of m.
+func (s *MatrixStack) Load(m *Matrix4) {
+	s.stack[s.top].CopyFrom(m)
+}
+
+// LoadIdentity replaces the top matrix with identity.
+func (s *MatrixStack) LoadIdentity() {
+	s.stack[s.top].SetIdentity()
+}
+
+// Multiply multiplies the top matrix by another matrix.
+func (s *MatrixStack) Multiply(n *Matrix4) {
+	s.stack[s.top].Multiply(n)
+}
+
+// Translate multiplies the top matrix by a translation matrix.
+// usually set w to 1.0
+func (s *MatrixStack) Translate(tx, ty, tz, tw float64) {
+	s.stack[s.top].Translate(tx, ty, tz, tw)
+}
+
+// Scale multiplies the top matrix by a scaling matrix.
+// usually set w to 1.0
+func (s *MatrixStack) Scale(x, y, z, w float64) {
+	s.stack[s.top].Scale(x, y, z, w)
+}
+
+// Rotate multiplies the top matrix by a rotation matrix built from specified forward and up vectors.
+// See Matrix4.Rotate.
+func (s *MatrixStack) Rotate(forwardX, forwardY, forwardZ, upX, upY, upZ float64) {
+	s.stack[s.top].Rotate(forwardX, forwardY, forwardZ, upX, upY, upZ)
+}
+
+// RotateQuaternion multiplies the top matrix by the rotation matrix built from the quaternion q.
+func (s *MatrixStack) RotateQuaternion(q Quaternion) {
+	s.stack[s.top].RotateQuaternion(q)
+}
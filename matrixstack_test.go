@@ -0,0 +1,64 @@
+package goglmath
+
+import (
+	"testing"
+)
+
+func TestMatrixStackPushPop(t *testing.T) {
+	s := NewMatrixStack()
+	if !s.Top().Identity() {
+		t.Errorf("new stack top is not identity: %v", s.Top())
+	}
+	s.Translate(1, 2, 3, 1)
+	saved := *s.Top()
+	if err := s.Push(); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	if !Matrix4Equal(s.Top(), &saved) {
+		t.Errorf("push did not duplicate top: expected=%v got=%v", saved, *s.Top())
+	}
+	s.Scale(2, 2, 2, 1)
+	if err := s.Pop(); err != nil {
+		t.Fatalf("pop: %v", err)
+	}
+	if !Matrix4Equal(s.Top(), &saved) {
+		t.Errorf("pop did not restore top: expected=%v got=%v", saved, *s.Top())
+	}
+	if err := s.Pop(); err != ErrMatrixStackUnderflow {
+		t.Errorf("expected=%v got=%v", ErrMatrixStackUnderflow, err)
+	}
+}
+
+func TestMatrixStackFixed(t *testing.T) {
+	s := NewMatrixStackFixed(2)
+	if err := s.Push(); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	if err := s.Push(); err != ErrMatrixStackOverflow {
+		t.Errorf("expected=%v got=%v", ErrMatrixStackOverflow, err)
+	}
+	if d := s.Depth(); d != 2 {
+		t.Errorf("depth: expected=2 got=%d", d)
+	}
+}
+
+func TestMatrixStackAllocs(t *testing.T) {
+	s := NewMatrixStackFixed(4)
+	allocs := testing.AllocsPerRun(100, func() {
+		s.Push()
+		s.Translate(1, 2, 3, 1)
+		s.Rotate(0, 0, -1, 0, 1, 0)
+		s.Pop()
+	})
+	if allocs != 0 {
+		t.Errorf("expected=0 allocations got=%v", allocs)
+	}
+}
+
+func BenchmarkMatrixStackPushPop(b *testing.B) {
+	s := NewMatrixStackFixed(2)
+	for n := 0; n < b.N; n++ {
+		s.Push()
+		s.Pop()
+	}
+}
@@ -0,0 +1,328 @@
+package goglmath
+
+import (
+	"math"
+)
+
+// Quaternion represents a rotation as X*i + Y*j + Z*k + W.
+// Rotation quaternions are unit quaternions.
+type Quaternion struct {
+	X, Y, Z, W float64
+}
+
+// NewQuaternionIdentity creates the null rotation.
+func NewQuaternionIdentity() Quaternion {
+	return Quaternion{0, 0, 0, 1}
+}
+
+// NewQuaternionAxisAngle creates a rotation of angleRadians around axis.
+// Positive angles rotate counterclockwise when looking from the tip of axis towards the origin.
+func NewQuaternionAxisAngle(axis Vector3, angleRadians float64) Quaternion {
+	a := axis.Normalize()
+	sin, cos := math.Sincos(angleRadians * .5)
+	return Quaternion{a.X * sin, a.Y * sin, a.Z * sin, cos}
+}
+
+// NewQuaternionEuler creates a rotation from Euler angles in radians.
+// pitch rotates around X, yaw rotates around Y, roll rotates around Z.
+// Rotations are applied in order roll, pitch, yaw: q = yaw * pitch * roll
+func NewQuaternionEuler(pitch, yaw, roll float64) Quaternion {
+	sp, cp := math.Sincos(pitch * .5)
+	sy, cy := math.Sincos(yaw * .5)
+	sr, cr := math.Sincos(roll * .5)
+	return Quaternion{
+		X: cy*sp*cr + sy*cp*sr,
+		Y: sy*cp*cr - cy*sp*sr,
+		Z: cy*cp*sr - sy*sp*cr,
+		W: cy*cp*cr + sy*sp*sr,
+	}
+}
+
+// NewQuaternionFromTo creates the shortest arc rotation taking direction from to direction to.
+func NewQuaternionFromTo(from, to Vector3) Quaternion {
+	f := from.Normalize()
+	t := to.Normalize()
+	d := f.Dot(t)
+	if d < -1+0.000001 {
+		// opposite directions: rotate 180 degrees around any axis orthogonal to from
+		axis := Vector3{1, 0, 0}.Cross(f)
+		if axis.LengthSquared() < 0.000001 {
+			axis = Vector3{0, 1, 0}.Cross(f)
+		}
+		return NewQuaternionAxisAngle(axis, math.Pi)
+	}
+	c := f.Cross(t)
+	return Quaternion{c.X, c.Y, c.Z, 1 + d}.Normalize()
+}
+
+// NewQuaternionForwardUp creates the rotation taking the null rotation direction to the direction specified by forward and up vectors.
+// This is the same rotation built by SetRotationMatrix, except up is orthogonalized against forward.
+//
+// null rotation:
+// forward = 0 0 -1 // looking towards -Z
+// up = 0 1 0       // up direction is +Y
+func NewQuaternionForwardUp(forward, up Vector3) Quaternion {
+	back := forward.Normalize().Negate()
+	right := forward.Cross(up).Normalize()
+	newUp := back.Cross(right)
+	return quaternionFromRotation(
+		right.X, newUp.X, back.X,
+		right.Y, newUp.Y, back.Y,
+		right.Z, newUp.Z, back.Z,
+	)
+}
+
+// quaternionFromRotation converts the rotation matrix given in row-major order.
+func quaternionFromRotation(r00, r01, r02, r10, r11, r12, r20, r21, r22 float64) Quaternion {
+	var q Quaternion
+	trace := r00 + r11 + r22
+	switch {
+	case trace > 0:
+		s := .5 / math.Sqrt(trace+1)
+		q.W = .25 / s
+		q.X = (r21 - r12) * s
+		q.Y = (r02 - r20) * s
+		q.Z = (r10 - r01) * s
+	case r00 > r11 && r00 > r22:
+		s := 2 * math.Sqrt(1+r00-r11-r22)
+		q.W = (r21 - r12) / s
+		q.X = .25 * s
+		q.Y = (r01 + r10) / s
+		q.Z = (r02 + r20) / s
+	case r11 > r22:
+		s := 2 * math.Sqrt(1+r11-r00-r22)
+		q.W = (r02 - r20) / s
+		q.X = (r01 + r10) / s
+		q.Y = .25 * s
+		q.Z = (r12 + r21) / s
+	default:
+		s := 2 * math.Sqrt(1+r22-r00-r11)
+		q.W = (r10 - r01) / s
+		q.X = (r02 + r20) / s
+		q.Y = (r12 + r21) / s
+		q.Z = .25 * s
+	}
+	return q.Normalize()
+}
+
+// Multiply calculates the product q*r.
+// The resulting rotation applies r first, then q.
+func (q Quaternion) Multiply(r Quaternion) Quaternion {
+	return Quaternion{
+		X: q.W*r.X + q.X*r.W + q.Y*r.Z - q.Z*r.Y,
+		Y: q.W*r.Y - q.X*r.Z + q.Y*r.W + q.Z*r.X,
+		Z: q.W*r.Z + q.X*r.Y - q.Y*r.X + q.Z*r.W,
+		W: q.W*r.W - q.X*r.X - q.Y*r.Y - q.Z*r.Z,
+	}
+}
+
+// Conjugate calculates the conjugate of the quaternion.
+// For unit quaternions, the conjugate is the inverse rotation.
+func (q Quaternion) Conjugate() Quaternion {
+	return Quaternion{-q.X, -q.Y, -q.Z, q.W}
+}
+
+// Inverse calculates the inverse of the quaternion.
+// The null quaternion is returned unchanged.
+func (q Quaternion) Inverse() Quaternion {
+	lenSq := q.Dot(q)
+	if lenSq == 0 {
+		return q
+	}
+	inv := 1.0 / lenSq
+	return Quaternion{-q.X * inv, -q.Y * inv, -q.Z * inv, q.W * inv}
+}
+
+// Dot calculates the dot product of two quaternions.
+func (q Quaternion) Dot(r Quaternion) float64 {
+	return q.X*r.X + q.Y*r.Y + q.Z*r.Z + q.W*r.W
+}
+
+// Length calculates the length of the quaternion.
+func (q Quaternion) Length() float64 {
+	return math.Sqrt(q.Dot(q))
+}
+
+// Normalize calculates a normalized copy of the quaternion.
+// The null quaternion is returned unchanged.
+func (q Quaternion) Normalize() Quaternion {
+	length := q.Length()
+	if length == 0 {
+		return q
+	}
+	inv := 1.0 / length
+	return Quaternion{q.X * inv, q.Y * inv, q.Z * inv, q.W * inv}
+}
+
+// Rotate applies the rotation q to vector v.
+func (q Quaternion) Rotate(v Vector3) Vector3 {
+	u := Vector3{q.X, q.Y, q.Z}
+	t := u.Cross(v).Scale(2)
+	return v.Add(t.Scale(q.W)).Add(u.Cross(t))
+}
+
+// AxisAngle converts the rotation to axis and angle in radians.
+// The null rotation reports axis +X.
+func (q Quaternion) AxisAngle() (Vector3, float64) {
+	n := q.Normalize()
+	if n.W < 0 {
+		n = Quaternion{-n.X, -n.Y, -n.Z, -n.W}
+	}
+	s := math.Sqrt(1 - n.W*n.W)
+	if s < 0.000001 {
+		return Vector3{1, 0, 0}, 0
+	}
+	return Vector3{n.X / s, n.Y / s, n.Z / s}, 2 * math.Acos(n.W)
+}
+
+// Log calculates the logarithm of the unit quaternion.
+func (q Quaternion) Log() Quaternion {
+	v := Vector3{q.X, q.Y, q.Z}
+	s := v.Length()
+	if s < 0.000001 {
+		return Quaternion{q.X, q.Y, q.Z, 0}
+	}
+	a := math.Atan2(s, q.W) / s
+	return Quaternion{q.X * a, q.Y * a, q.Z * a, 0}
+}
+
+// Exp calculates the exponential of the pure quaternion (W=0), the inverse of Log.
+func (q Quaternion) Exp() Quaternion {
+	a := Vector3{q.X, q.Y, q.Z}.Length()
+	sin, cos := math.Sincos(a)
+	if a < 0.000001 {
+		return Quaternion{q.X, q.Y, q.Z, cos}
+	}
+	s := sin / a
+	return Quaternion{q.X * s, q.Y * s, q.Z * s, cos}
+}
+
+// Nlerp interpolates from q (t=0) to r (t=1) by normalized linear interpolation.
+// Nlerp is cheaper than Slerp, but does not keep constant angular velocity.
+// The shortest path is taken.
+func (q Quaternion) Nlerp(r Quaternion, t float64) Quaternion {
+	if q.Dot(r) < 0 {
+		r = Quaternion{-r.X, -r.Y, -r.Z, -r.W}
+	}
+	return Quaternion{
+		q.X + (r.X-q.X)*t,
+		q.Y + (r.Y-q.Y)*t,
+		q.Z + (r.Z-q.Z)*t,
+		q.W + (r.W-q.W)*t,
+	}.Normalize()
+}
+
+// Slerp interpolates from q (t=0) to r (t=1) by spherical linear interpolation.
+// The shortest path is taken.
+func (q Quaternion) Slerp(r Quaternion, t float64) Quaternion {
+	d := q.Dot(r)
+	if d < 0 {
+		r = Quaternion{-r.X, -r.Y, -r.Z, -r.W}
+		d = -d
+	}
+	if d > 0.9995 {
+		// nearly parallel: avoid division by sin(theta)=0
+		return q.Nlerp(r, t)
+	}
+	theta := math.Acos(d)
+	sinTheta := math.Sin(theta)
+	a := math.Sin((1-t)*theta) / sinTheta
+	b := math.Sin(t*theta) / sinTheta
+	return Quaternion{
+		q.X*a + r.X*b,
+		q.Y*a + r.Y*b,
+		q.Z*a + r.Z*b,
+		q.W*a + r.W*b,
+	}
+}
+
+// slerpNoInvert is slerp without shortest path correction, as required by squad.
+func (q Quaternion) slerpNoInvert(r Quaternion, t float64) Quaternion {
+	d := q.Dot(r)
+	if d > 0.9995 || d < -0.9995 {
+		return Quaternion{
+			q.X + (r.X-q.X)*t,
+			q.Y + (r.Y-q.Y)*t,
+			q.Z + (r.Z-q.Z)*t,
+			q.W + (r.W-q.W)*t,
+		}.Normalize()
+	}
+	theta := math.Acos(d)
+	sinTheta := math.Sin(theta)
+	a := math.Sin((1-t)*theta) / sinTheta
+	b := math.Sin(t*theta) / sinTheta
+	return Quaternion{
+		q.X*a + r.X*b,
+		q.Y*a + r.Y*b,
+		q.Z*a + r.Z*b,
+		q.W*a + r.W*b,
+	}
+}
+
+// Squad interpolates from q1 (t=0) to q2 (t=1) by spherical quadrangle interpolation.
+// a and b are the control points for q1 and q2, usually computed by SquadControlPoint.
+// Squad provides smooth (C1 continuous) interpolation across a sequence of rotations.
+func Squad(q1, a, b, q2 Quaternion, t float64) Quaternion {
+	return q1.slerpNoInvert(q2, t).slerpNoInvert(a.slerpNoInvert(b, t), 2*t*(1-t))
+}
+
+// SquadControlPoint calculates the squad control point for rotation cur, given its neighbors prev and next in the sequence.
+func SquadControlPoint(prev, cur, next Quaternion) Quaternion {
+	inv := cur.Conjugate()
+	l1 := inv.Multiply(next).Log()
+	l2 := inv.Multiply(prev).Log()
+	e := Quaternion{
+		-(l1.X + l2.X) * .25,
+		-(l1.Y + l2.Y) * .25,
+		-(l1.Z + l2.Z) * .25,
+		0,
+	}.Exp()
+	return cur.Multiply(e)
+}
+
+// SetQuaternionMatrix builds the rotation matrix for the unit quaternion q.
+func SetQuaternionMatrix(rotationMatrix *Matrix4, q Quaternion) {
+	xx := q.X * q.X
+	yy := q.Y * q.Y
+	zz := q.Z * q.Z
+	xy := q.X * q.Y
+	xz := q.X * q.Z
+	yz := q.Y * q.Z
+	wx := q.W * q.X
+	wy := q.W * q.Y
+	wz := q.W * q.Z
+
+	rotationMatrix.data[0] = float32(1 - 2*(yy+zz))
+	rotationMatrix.data[1] = float32(2 * (xy + wz))
+	rotationMatrix.data[2] = float32(2 * (xz - wy))
+	rotationMatrix.data[3] = 0
+	rotationMatrix.data[4] = float32(2 * (xy - wz))
+	rotationMatrix.data[5] = float32(1 - 2*(xx+zz))
+	rotationMatrix.data[6] = float32(2 * (yz + wx))
+	rotationMatrix.data[7] = 0
+	rotationMatrix.data[8] = float32(2 * (xz + wy))
+	rotationMatrix.data[9] = float32(2 * (yz - wx))
+	rotationMatrix.data[10] = float32(1 - 2*(xx+yy))
+	rotationMatrix.data[11] = 0
+	rotationMatrix.data[12] = 0
+	rotationMatrix.data[13] = 0
+	rotationMatrix.data[14] = 0
+	rotationMatrix.data[15] = 1
+}
+
+// Quaternion extracts the rotation from the upper 3x3 of the matrix.
+// The upper 3x3 is assumed to be a pure rotation (orthonormal, no scaling).
+func (m *Matrix4) Quaternion() Quaternion {
+	return quaternionFromRotation(
+		float64(m.data[0]), float64(m.data[4]), float64(m.data[8]),
+		float64(m.data[1]), float64(m.data[5]), float64(m.data[9]),
+		float64(m.data[2]), float64(m.data[6]), float64(m.data[10]),
+	)
+}
+
+// RotateQuaternion multiplies the matrix m by the rotation matrix built from the quaternion q.
+func (m *Matrix4) RotateQuaternion(q Quaternion) {
+	var rotate Matrix4
+	SetQuaternionMatrix(&rotate, q)
+	m.Multiply(&rotate)
+}
@@ -0,0 +1,121 @@
+package goglmath
+
+import (
+	"math"
+	"testing"
+)
+
+func vector3Close(v, u Vector3) bool {
+	return closeToZero(v.Distance(u))
+}
+
+func quaternionClose(q, r Quaternion) bool {
+	// q and -q represent the same rotation
+	return closeToZero(1 - math.Abs(q.Dot(r)))
+}
+
+func matrix4Close(m1, m2 *Matrix4, tolerance float32) bool {
+	for i, v := range m1.data {
+		d := v - m2.data[i]
+		if d > tolerance || d < -tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+func TestQuaternionAxisAngle(t *testing.T) {
+	q := NewQuaternionAxisAngle(Vector3{0, 0, 1}, math.Pi/2)
+	v := q.Rotate(Vector3{1, 0, 0})
+	if want := (Vector3{0, 1, 0}); !vector3Close(v, want) {
+		t.Errorf("expected=%v got=%v", want, v)
+	}
+	axis, a := q.AxisAngle()
+	if !vector3Close(axis, Vector3{0, 0, 1}) || !closeToZero(a-math.Pi/2) {
+		t.Errorf("axis-angle: got axis=%v angle=%v", axis, a)
+	}
+}
+
+func TestQuaternionMatrixRoundTrip(t *testing.T) {
+	q := NewQuaternionEuler(.3, -1.2, 2.5)
+	var m Matrix4
+	SetQuaternionMatrix(&m, q)
+	r := m.Quaternion()
+	if !quaternionClose(q, r) {
+		t.Errorf("round trip: expected=%v got=%v", q, r)
+	}
+	v := Vector3{1, 2, 3}
+	if a, b := q.Rotate(v), m.TransformDirection(v); !vector3Close(a, b) {
+		t.Errorf("quaternion rotation %v != matrix rotation %v", a, b)
+	}
+}
+
+func TestQuaternionForwardUp(t *testing.T) {
+	var rotate Matrix4
+	SetRotationMatrix(&rotate, 0, 0, -1, 0, -1, 0) // upside down
+	q := NewQuaternionForwardUp(Vector3{0, 0, -1}, Vector3{0, -1, 0})
+	var m Matrix4
+	SetQuaternionMatrix(&m, q)
+	if !matrix4Close(&rotate, &m, 0.000001) {
+		t.Errorf("mismatch: rotation=%v quaternion=%v", rotate, m)
+	}
+}
+
+func TestQuaternionEuler(t *testing.T) {
+	pitch := NewQuaternionAxisAngle(Vector3{1, 0, 0}, .4)
+	yaw := NewQuaternionAxisAngle(Vector3{0, 1, 0}, .5)
+	roll := NewQuaternionAxisAngle(Vector3{0, 0, 1}, .6)
+	want := yaw.Multiply(pitch).Multiply(roll)
+	q := NewQuaternionEuler(.4, .5, .6)
+	if !quaternionClose(q, want) {
+		t.Errorf("expected=%v got=%v", want, q)
+	}
+}
+
+func TestQuaternionFromTo(t *testing.T) {
+	from := Vector3{1, 2, 3}
+	to := Vector3{-3, 1, 0.5}
+	q := NewQuaternionFromTo(from, to)
+	if got, want := q.Rotate(from.Normalize()), to.Normalize(); !vector3Close(got, want) {
+		t.Errorf("expected=%v got=%v", want, got)
+	}
+	q = NewQuaternionFromTo(Vector3{1, 0, 0}, Vector3{-1, 0, 0})
+	if got := q.Rotate(Vector3{1, 0, 0}); !vector3Close(got, Vector3{-1, 0, 0}) {
+		t.Errorf("opposite: got=%v", got)
+	}
+}
+
+func TestQuaternionSlerp(t *testing.T) {
+	q := NewQuaternionIdentity()
+	r := NewQuaternionAxisAngle(Vector3{0, 1, 0}, 2)
+	half := q.Slerp(r, .5)
+	if want := NewQuaternionAxisAngle(Vector3{0, 1, 0}, 1); !quaternionClose(half, want) {
+		t.Errorf("slerp: expected=%v got=%v", want, half)
+	}
+	if got := q.Nlerp(r, .5); !quaternionClose(got, half) {
+		t.Errorf("nlerp: expected=%v got=%v", half, got)
+	}
+}
+
+func TestQuaternionSquad(t *testing.T) {
+	q0 := NewQuaternionAxisAngle(Vector3{1, 0, 0}, .1)
+	q1 := NewQuaternionAxisAngle(Vector3{0, 1, 0}, .7)
+	q2 := NewQuaternionAxisAngle(Vector3{0, 0, 1}, 1.2)
+	q3 := NewQuaternionAxisAngle(Vector3{1, 1, 0}, .3)
+	a := SquadControlPoint(q0, q1, q2)
+	b := SquadControlPoint(q1, q2, q3)
+	if got := Squad(q1, a, b, q2, 0); !quaternionClose(got, q1) {
+		t.Errorf("t=0: expected=%v got=%v", q1, got)
+	}
+	if got := Squad(q1, a, b, q2, 1); !quaternionClose(got, q2) {
+		t.Errorf("t=1: expected=%v got=%v", q2, got)
+	}
+}
+
+func BenchmarkQuaternionRotate(b *testing.B) {
+	q := NewQuaternionEuler(.1, .2, .3)
+	v := Vector3{1, 2, 3}
+	for n := 0; n < b.N; n++ {
+		q.Rotate(v)
+	}
+}
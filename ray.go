@@ -0,0 +1,354 @@
+package goglmath
+
+import (
+	"math"
+)
+
+// Ray is a half-line starting at Origin towards unit Direction.
+type Ray struct {
+	Origin    Vector3
+	Direction Vector3
+}
+
+// RayHit describes the intersection of a ray with a surface.
+// Normal is the unit surface normal at the hit point.
+// For closed surfaces (sphere, boxes, capsule, cylinder) Normal points outwards.
+// For open surfaces (plane, disc, triangle) Normal faces the ray origin.
+type RayHit struct {
+	Distance float64 // distance from ray origin to hit point
+	Point    Vector3
+	Normal   Vector3
+}
+
+// TriangleHit describes the intersection of a ray with a triangle.
+// Hit point = (1-U-V)*a + U*b + V*c
+type TriangleHit struct {
+	RayHit
+	U, V float64 // barycentric coordinates of the hit point
+}
+
+// NewRay creates a ray from origin towards direction.
+func NewRay(origin, direction Vector3) Ray {
+	return Ray{origin, direction.Normalize()}
+}
+
+// NewRayFromPoints creates a ray from point from towards point to.
+func NewRayFromPoints(from, to Vector3) Ray {
+	return NewRay(from, to.Sub(from))
+}
+
+// NewPickRay creates the ray from the near plane towards the far plane through pickX,pickY.
+// See PickRay.
+func NewPickRay(camera *Matrix4, viewportX, viewportWidth, viewportY, viewportHeight, pickX, pickY int) (Ray, error) {
+	return NewPickRayClip(camera, ClipSpaceOpenGL, viewportX, viewportWidth, viewportY, viewportHeight, pickX, pickY)
+}
+
+// NewPickRayClip creates the ray through pickX,pickY for the given clip space convention.
+// See PickRayClip.
+func NewPickRayClip(camera *Matrix4, clip ClipSpace, viewportX, viewportWidth, viewportY, viewportHeight, pickX, pickY int) (Ray, error) {
+	nearX, nearY, nearZ, farX, farY, farZ, err := PickRayClip(camera, clip, viewportX, viewportWidth, viewportY, viewportHeight, pickX, pickY)
+	if err != nil {
+		return Ray{}, err
+	}
+	return NewRayFromPoints(Vector3{nearX, nearY, nearZ}, Vector3{farX, farY, farZ}), nil
+}
+
+// At calculates the point at distance t along the ray.
+func (r Ray) At(t float64) Vector3 {
+	return r.Origin.Add(r.Direction.Scale(t))
+}
+
+func (r Ray) hit(t float64, normal Vector3) RayHit {
+	return RayHit{Distance: t, Point: r.At(t), Normal: normal}
+}
+
+// facing flips normal to face the ray origin.
+func (r Ray) facing(normal Vector3) Vector3 {
+	if normal.Dot(r.Direction) > 0 {
+		return normal.Negate()
+	}
+	return normal
+}
+
+// IntersectPlane finds the intersection of the ray with the plane.
+func (r Ray) IntersectPlane(p Plane) (RayHit, bool) {
+	denom := p.Normal.Dot(r.Direction)
+	if closeToZero(denom) {
+		return RayHit{}, false // parallel
+	}
+	t := -p.Distance(r.Origin) / denom
+	if t < 0 {
+		return RayHit{}, false
+	}
+	return r.hit(t, r.facing(p.Normal.Normalize())), true
+}
+
+// sphereRoots finds the ray distances where the ray crosses the sphere surface, t0 <= t1.
+func (r Ray) sphereRoots(center Vector3, radius float64) (t0, t1 float64, ok bool) {
+	oc := r.Origin.Sub(center)
+	b := oc.Dot(r.Direction)
+	c := oc.LengthSquared() - radius*radius
+	h := b*b - c
+	if h < 0 {
+		return 0, 0, false
+	}
+	h = math.Sqrt(h)
+	return -b - h, -b + h, true
+}
+
+// IntersectSphere finds the nearest intersection of the ray with the sphere surface.
+// If the ray starts inside the sphere, the exit point is reported.
+func (r Ray) IntersectSphere(s Sphere) (RayHit, bool) {
+	t0, t1, ok := r.sphereRoots(s.Center, s.Radius)
+	if !ok || t1 < 0 {
+		return RayHit{}, false
+	}
+	t := t0
+	if t < 0 {
+		t = t1
+	}
+	h := r.hit(t, Vector3{})
+	h.Normal = h.Point.Sub(s.Center).Normalize()
+	return h, true
+}
+
+// slabs intersects the ray, given in box local space, with the box centered at origin.
+// The hit normal is returned in box local space.
+func slabs(origin, direction, halfExtents Vector3) (RayHit, bool) {
+	o := [3]float64{origin.X, origin.Y, origin.Z}
+	d := [3]float64{direction.X, direction.Y, direction.Z}
+	e := [3]float64{halfExtents.X, halfExtents.Y, halfExtents.Z}
+
+	tNear := math.Inf(-1)
+	tFar := math.Inf(1)
+	var nearAxis, farAxis int
+	var nearSign, farSign float64
+
+	for i := 0; i < 3; i++ {
+		if d[i] == 0 {
+			if o[i] < -e[i] || o[i] > e[i] {
+				return RayHit{}, false // parallel to slab and outside it
+			}
+			continue
+		}
+		inv := 1.0 / d[i]
+		t1 := (-e[i] - o[i]) * inv
+		t2 := (e[i] - o[i]) * inv
+		sign := -1.0 // entering through the min face
+		if t1 > t2 {
+			t1, t2 = t2, t1
+			sign = 1.0 // entering through the max face
+		}
+		if t1 > tNear {
+			tNear, nearAxis, nearSign = t1, i, sign
+		}
+		if t2 < tFar {
+			tFar, farAxis, farSign = t2, i, -sign
+		}
+		if tNear > tFar || tFar < 0 {
+			return RayHit{}, false
+		}
+	}
+
+	t, axis, sign := tNear, nearAxis, nearSign
+	if t < 0 {
+		// ray starts inside the box
+		t, axis, sign = tFar, farAxis, farSign
+	}
+	if math.IsInf(t, 0) {
+		return RayHit{}, false // null direction
+	}
+	var n [3]float64
+	n[axis] = sign
+	return RayHit{Distance: t, Normal: Vector3{n[0], n[1], n[2]}}, true
+}
+
+// IntersectAABB finds the nearest intersection of the ray with the axis-aligned box surface.
+// If the ray starts inside the box, the exit point is reported.
+func (r Ray) IntersectAABB(b AABB) (RayHit, bool) {
+	center := b.Center()
+	h, ok := slabs(r.Origin.Sub(center), r.Direction, b.HalfExtents())
+	if !ok {
+		return RayHit{}, false
+	}
+	return r.hit(h.Distance, h.Normal), true
+}
+
+// IntersectOBB finds the nearest intersection of the ray with the oriented box surface.
+// If the ray starts inside the box, the exit point is reported.
+func (r Ray) IntersectOBB(b OBB) (RayHit, bool) {
+	oc := r.Origin.Sub(b.Center)
+	localOrigin := Vector3{oc.Dot(b.Axes[0]), oc.Dot(b.Axes[1]), oc.Dot(b.Axes[2])}
+	localDir := Vector3{r.Direction.Dot(b.Axes[0]), r.Direction.Dot(b.Axes[1]), r.Direction.Dot(b.Axes[2])}
+	h, ok := slabs(localOrigin, localDir, b.HalfExtents)
+	if !ok {
+		return RayHit{}, false
+	}
+	n := b.Axes[0].Scale(h.Normal.X).Add(b.Axes[1].Scale(h.Normal.Y)).Add(b.Axes[2].Scale(h.Normal.Z))
+	return r.hit(h.Distance, n), true
+}
+
+// IntersectTriangle finds the intersection of the ray with the triangle a,b,c using the Möller–Trumbore algorithm.
+// Front faces have counterclockwise vertices when seen from the ray origin.
+// If cullBackface is true, back faces are not hit.
+func (r Ray) IntersectTriangle(a, b, c Vector3, cullBackface bool) (TriangleHit, bool) {
+	const epsilon = 0.0000001
+
+	e1 := b.Sub(a)
+	e2 := c.Sub(a)
+	p := r.Direction.Cross(e2)
+	det := e1.Dot(p)
+	// |det| <= |e1|*|e2|*|direction|: the tolerance is relative, hence independent of scene units
+	tolerance := epsilon * e1.Length() * e2.Length() * r.Direction.Length()
+	if cullBackface {
+		if !(det > tolerance) {
+			return TriangleHit{}, false
+		}
+	} else if !(math.Abs(det) > tolerance) {
+		return TriangleHit{}, false // ray parallel to triangle
+	}
+	invDet := 1.0 / det
+
+	s := r.Origin.Sub(a)
+	u := s.Dot(p) * invDet
+	if u < 0 || u > 1 {
+		return TriangleHit{}, false
+	}
+	q := s.Cross(e1)
+	v := r.Direction.Dot(q) * invDet
+	if v < 0 || u+v > 1 {
+		return TriangleHit{}, false
+	}
+	t := e2.Dot(q) * invDet
+	if t < 0 {
+		return TriangleHit{}, false
+	}
+
+	n := r.facing(e1.Cross(e2).Normalize())
+	return TriangleHit{RayHit: r.hit(t, n), U: u, V: v}, true
+}
+
+// IntersectDisc finds the intersection of the ray with the disc.
+func (r Ray) IntersectDisc(center, normal Vector3, radius float64) (RayHit, bool) {
+	h, ok := r.IntersectPlane(NewPlane(normal, center))
+	if !ok || h.Point.DistanceSquared(center) > radius*radius {
+		return RayHit{}, false
+	}
+	return h, true
+}
+
+// cylinderRoots finds the ray distances where the ray crosses the infinite cylinder surface around axis a->b, t0 <= t1.
+func (r Ray) cylinderRoots(a, b Vector3, radius float64) (t0, t1 float64, ok bool) {
+	axis := b.Sub(a).Normalize()
+	oc := r.Origin.Sub(a)
+	d := r.Direction.Reject(axis)
+	o := oc.Reject(axis)
+	qa := d.LengthSquared()
+	if closeToZero(qa) {
+		return 0, 0, false // parallel to axis
+	}
+	qb := d.Dot(o)
+	qc := o.LengthSquared() - radius*radius
+	h := qb*qb - qa*qc
+	if h < 0 {
+		return 0, 0, false
+	}
+	h = math.Sqrt(h)
+	return (-qb - h) / qa, (-qb + h) / qa, true
+}
+
+// nearest keeps the candidate hit with the smallest non-negative distance.
+type nearest struct {
+	hit RayHit
+	ok  bool
+}
+
+func (n *nearest) add(h RayHit) {
+	if h.Distance < 0 {
+		return
+	}
+	if !n.ok || h.Distance < n.hit.Distance {
+		n.hit = h
+		n.ok = true
+	}
+}
+
+// bodyHits adds the hits with the cylinder body between a and b.
+func (r Ray) bodyHits(n *nearest, a, b Vector3, radius float64) {
+	t0, t1, ok := r.cylinderRoots(a, b, radius)
+	if !ok {
+		return
+	}
+	ba := b.Sub(a)
+	baba := ba.LengthSquared()
+	for _, t := range []float64{t0, t1} {
+		p := r.At(t)
+		y := p.Sub(a).Dot(ba)
+		if y < 0 || y > baba {
+			continue
+		}
+		normal := p.Sub(a).Reject(ba).Normalize()
+		n.add(RayHit{Distance: t, Point: p, Normal: normal})
+	}
+}
+
+// IntersectCapsule finds the nearest intersection of the ray with the capsule around segment a->b.
+// If the ray starts inside the capsule, the exit point is reported.
+func (r Ray) IntersectCapsule(a, b Vector3, radius float64) (RayHit, bool) {
+	var n nearest
+	r.bodyHits(&n, a, b, radius)
+
+	ba := b.Sub(a)
+	caps := []struct {
+		center Vector3
+		side   float64 // hemisphere outside the body
+	}{
+		{a, -1},
+		{b, 1},
+	}
+	for _, c := range caps {
+		t0, t1, ok := r.sphereRoots(c.center, radius)
+		if !ok {
+			continue
+		}
+		for _, t := range []float64{t0, t1} {
+			p := r.At(t)
+			if p.Sub(c.center).Dot(ba)*c.side < 0 {
+				continue // inside the body
+			}
+			n.add(RayHit{Distance: t, Point: p, Normal: p.Sub(c.center).Normalize()})
+		}
+	}
+
+	return n.hit, n.ok
+}
+
+// IntersectCylinder finds the nearest intersection of the ray with the capped cylinder around segment a->b.
+// If the ray starts inside the cylinder, the exit point is reported.
+func (r Ray) IntersectCylinder(a, b Vector3, radius float64) (RayHit, bool) {
+	var n nearest
+	r.bodyHits(&n, a, b, radius)
+
+	axis := b.Sub(a).Normalize()
+	caps := []struct {
+		center Vector3
+		normal Vector3
+	}{
+		{a, axis.Negate()},
+		{b, axis},
+	}
+	for _, c := range caps {
+		denom := c.normal.Dot(r.Direction)
+		if closeToZero(denom) {
+			continue
+		}
+		t := -c.normal.Dot(r.Origin.Sub(c.center)) / denom
+		p := r.At(t)
+		if p.DistanceSquared(c.center) > radius*radius {
+			continue
+		}
+		n.add(RayHit{Distance: t, Point: p, Normal: c.normal})
+	}
+
+	return n.hit, n.ok
+}
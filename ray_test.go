@@ -0,0 +1,147 @@
+package goglmath
+
+import (
+	"math"
+	"testing"
+)
+
+func checkHit(t *testing.T, label string, h RayHit, ok bool, distance float64, normal Vector3) {
+	t.Helper()
+	if !ok {
+		t.Errorf("%s: missed", label)
+		return
+	}
+	if !closeToZero(h.Distance - distance) {
+		t.Errorf("%s: distance: expected=%v got=%v", label, distance, h.Distance)
+	}
+	if !vector3Close(h.Normal, normal) {
+		t.Errorf("%s: normal: expected=%v got=%v", label, normal, h.Normal)
+	}
+}
+
+func TestRayPlane(t *testing.T) {
+	r := NewRay(Vector3{0, 5, 0}, Vector3{0, -1, 0})
+	h, ok := r.IntersectPlane(NewPlane(Vector3{0, 1, 0}, Vector3{0, 1, 0}))
+	checkHit(t, "plane", h, ok, 4, Vector3{0, 1, 0})
+	if _, ok := r.IntersectPlane(NewPlane(Vector3{1, 0, 0}, Vector3{})); ok {
+		t.Errorf("parallel plane: unexpected hit")
+	}
+}
+
+func TestRaySphere(t *testing.T) {
+	r := NewRay(Vector3{0, 0, 10}, Vector3{0, 0, -1})
+	s := Sphere{Vector3{0, 0, 0}, 2}
+	h, ok := r.IntersectSphere(s)
+	checkHit(t, "outside", h, ok, 8, Vector3{0, 0, 1})
+
+	inside := NewRay(Vector3{}, Vector3{0, 0, -1})
+	h, ok = inside.IntersectSphere(s)
+	checkHit(t, "inside", h, ok, 2, Vector3{0, 0, -1})
+
+	if _, ok := NewRay(Vector3{0, 0, 10}, Vector3{0, 0, 1}).IntersectSphere(s); ok {
+		t.Errorf("sphere behind ray: unexpected hit")
+	}
+}
+
+func TestRayAABB(t *testing.T) {
+	b := AABB{Vector3{-1, -1, -1}, Vector3{1, 1, 1}}
+	h, ok := NewRay(Vector3{5, 0, 0}, Vector3{-1, 0, 0}).IntersectAABB(b)
+	checkHit(t, "outside", h, ok, 4, Vector3{1, 0, 0})
+	h, ok = NewRay(Vector3{}, Vector3{0, 1, 0}).IntersectAABB(b)
+	checkHit(t, "inside", h, ok, 1, Vector3{0, 1, 0})
+	if _, ok := NewRay(Vector3{5, 5, 0}, Vector3{-1, 0, 0}).IntersectAABB(b); ok {
+		t.Errorf("box beside ray: unexpected hit")
+	}
+}
+
+func TestRayOBB(t *testing.T) {
+	model := NewMatrix4Identity()
+	model.RotateQuaternion(NewQuaternionAxisAngle(Vector3{0, 0, 1}, math.Pi/4))
+	b := NewOBB(&model, AABB{Vector3{-1, -1, -1}, Vector3{1, 1, 1}})
+	h, ok := NewRay(Vector3{5, 0, 0}, Vector3{-1, 0, 0}).IntersectOBB(b)
+	checkHit(t, "obb", h, ok, 5-math.Sqrt2, Vector3{1, 1, 0}.Normalize())
+}
+
+func TestRayTriangle(t *testing.T) {
+	a := Vector3{0, 0, 0}
+	b := Vector3{1, 0, 0}
+	c := Vector3{0, 1, 0}
+	r := NewRay(Vector3{.25, .5, 3}, Vector3{0, 0, -1})
+	h, ok := r.IntersectTriangle(a, b, c, true)
+	checkHit(t, "front", h.RayHit, ok, 3, Vector3{0, 0, 1})
+	if !closeToZero(h.U-.25) || !closeToZero(h.V-.5) {
+		t.Errorf("barycentric: expected=.25,.5 got=%v,%v", h.U, h.V)
+	}
+
+	back := NewRay(Vector3{.25, .5, -3}, Vector3{0, 0, 1})
+	if _, ok := back.IntersectTriangle(a, b, c, true); ok {
+		t.Errorf("culled back face: unexpected hit")
+	}
+	h, ok = back.IntersectTriangle(a, b, c, false)
+	checkHit(t, "back", h.RayHit, ok, 3, Vector3{0, 0, -1})
+
+	if _, ok := NewRay(Vector3{1, 1, 3}, Vector3{0, 0, -1}).IntersectTriangle(a, b, c, false); ok {
+		t.Errorf("outside triangle: unexpected hit")
+	}
+
+	// small scale: edges of 1e-5
+	sa, sb, sc := a.Scale(.00001), b.Scale(.00001), c.Scale(.00001)
+	h, ok = NewRay(Vector3{.0000025, .000005, 3}, Vector3{0, 0, -1}).IntersectTriangle(sa, sb, sc, true)
+	checkHit(t, "small", h.RayHit, ok, 3, Vector3{0, 0, 1})
+	if _, ok := NewRay(Vector3{-1, .000005, 0}, Vector3{1, 0, 0}).IntersectTriangle(sa, sb, sc, false); ok {
+		t.Errorf("small parallel: unexpected hit")
+	}
+}
+
+func TestRayDisc(t *testing.T) {
+	r := NewRay(Vector3{.5, 3, 0}, Vector3{0, -1, 0})
+	h, ok := r.IntersectDisc(Vector3{}, Vector3{0, 1, 0}, 1)
+	checkHit(t, "disc", h, ok, 3, Vector3{0, 1, 0})
+	if _, ok := r.IntersectDisc(Vector3{}, Vector3{0, 1, 0}, .4); ok {
+		t.Errorf("small disc: unexpected hit")
+	}
+}
+
+func TestRayCapsule(t *testing.T) {
+	a := Vector3{0, -1, 0}
+	b := Vector3{0, 1, 0}
+	h, ok := NewRay(Vector3{5, 0, 0}, Vector3{-1, 0, 0}).IntersectCapsule(a, b, .5)
+	checkHit(t, "body", h, ok, 4.5, Vector3{1, 0, 0})
+	h, ok = NewRay(Vector3{0, 5, 0}, Vector3{0, -1, 0}).IntersectCapsule(a, b, .5)
+	checkHit(t, "cap", h, ok, 3.5, Vector3{0, 1, 0})
+	h, ok = NewRay(Vector3{}, Vector3{0, -1, 0}).IntersectCapsule(a, b, .5)
+	checkHit(t, "inside", h, ok, 1.5, Vector3{0, -1, 0})
+}
+
+func TestRayCylinder(t *testing.T) {
+	a := Vector3{0, -1, 0}
+	b := Vector3{0, 1, 0}
+	h, ok := NewRay(Vector3{5, 0, 0}, Vector3{-1, 0, 0}).IntersectCylinder(a, b, .5)
+	checkHit(t, "body", h, ok, 4.5, Vector3{1, 0, 0})
+	h, ok = NewRay(Vector3{.2, 5, 0}, Vector3{0, -1, 0}).IntersectCylinder(a, b, .5)
+	checkHit(t, "cap", h, ok, 4, Vector3{0, 1, 0})
+	if _, ok := NewRay(Vector3{5, 1.5, 0}, Vector3{-1, 0, 0}).IntersectCylinder(a, b, .5); ok {
+		t.Errorf("above cylinder: unexpected hit")
+	}
+}
+
+func TestNewPickRay(t *testing.T) {
+	var camera Matrix4
+	SetPerspectiveMatrix(&camera, math.Pi/2, 1, 1, 10)
+	r, err := NewPickRay(&camera, 0, 100, 0, 100, 50, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !vector3Close(r.Direction, Vector3{0, 0, -1}) {
+		t.Errorf("direction: expected=%v got=%v", Vector3{0, 0, -1}, r.Direction)
+	}
+	h, ok := r.IntersectSphere(Sphere{Vector3{0, 0, -5}, 1})
+	checkHit(t, "pick", h, ok, 3, Vector3{0, 0, 1})
+}
+
+func BenchmarkRayTriangle(b *testing.B) {
+	r := NewRay(Vector3{.25, .5, 3}, Vector3{0, 0, -1})
+	for n := 0; n < b.N; n++ {
+		r.IntersectTriangle(Vector3{0, 0, 0}, Vector3{1, 0, 0}, Vector3{0, 1, 0}, true)
+	}
+}
@@ -0,0 +1,479 @@
+package goglmath
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Serialization formats:
+//
+// Binary: little-endian IEEE 754, independent of host byte order.
+// Matrix4 is 16 float32 in column-major order (64 bytes).
+// Vectors and Quaternion are float64 components in field order.
+// gob uses the binary format.
+//
+// Text: Matrix4 uses the String format, see ParseMatrix4.
+// Vectors and Quaternion are components in field order, within brackets: [1 2 3]
+//
+// JSON: Matrix4 is a flat array of 16 numbers in column-major order, as Data.
+// Use Matrix4RowMajor or Matrix4ColumnMajor for nested arrays.
+// Vectors and Quaternion use their exported fields, not the text format.
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (m Matrix4) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 64)
+	for i, v := range m.data {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(v))
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (m *Matrix4) UnmarshalBinary(data []byte) error {
+	if len(data) != 64 {
+		return fmt.Errorf("unmarshalBinary: Matrix4 expects 64 bytes, found %d", len(data))
+	}
+	for i := range m.data {
+		m.data[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:]))
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m Matrix4) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *Matrix4) UnmarshalText(text []byte) error {
+	p, err := ParseMatrix4(string(text))
+	if err != nil {
+		return err
+	}
+	*m = p
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// The matrix is a flat array in column-major order.
+func (m Matrix4) MarshalJSON() ([]byte, error) {
+	return appendJSONFloats(nil, m.data[:])
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// As usual in encoding/json, null is a no-op.
+func (m *Matrix4) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var values []float64
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if len(values) != 16 {
+		return fmt.Errorf("unmarshalJSON: Matrix4 expects 16 elements, found %d", len(values))
+	}
+	for i, v := range values {
+		m.data[i] = float32(v)
+	}
+	return nil
+}
+
+// Matrix4RowMajor encodes a Matrix4 in JSON as an array of 4 rows.
+//
+// json.Marshal(Matrix4RowMajor(m)) // [[m00,m01,m02,m03],[m10,...],...]
+type Matrix4RowMajor Matrix4
+
+// Matrix4ColumnMajor encodes a Matrix4 in JSON as an array of 4 columns.
+//
+// json.Marshal(Matrix4ColumnMajor(m)) // [[m00,m10,m20,m30],[m01,...],...]
+type Matrix4ColumnMajor Matrix4
+
+// MarshalJSON implements json.Marshaler.
+func (m Matrix4RowMajor) MarshalJSON() ([]byte, error) {
+	return marshalJSONNested(&m.data, true)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *Matrix4RowMajor) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONNested(&m.data, data, true)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m Matrix4ColumnMajor) MarshalJSON() ([]byte, error) {
+	return marshalJSONNested(&m.data, false)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *Matrix4ColumnMajor) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONNested(&m.data, data, false)
+}
+
+func marshalJSONNested(d *[16]float32, rows bool) ([]byte, error) {
+	buf := []byte{'['}
+	for i := 0; i < 4; i++ {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		var line [4]float32
+		for j := range line {
+			if rows {
+				line[j] = d[j*4+i]
+			} else {
+				line[j] = d[i*4+j]
+			}
+		}
+		var err error
+		if buf, err = appendJSONFloats(buf, line[:]); err != nil {
+			return nil, err
+		}
+	}
+	return append(buf, ']'), nil
+}
+
+func unmarshalJSONNested(d *[16]float32, data []byte, rows bool) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var values [][]float64
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if len(values) != 4 {
+		return fmt.Errorf("unmarshalJSON: Matrix4 expects 4 nested arrays, found %d", len(values))
+	}
+	var out [16]float32 // d is left untouched on error
+	for i, line := range values {
+		if len(line) != 4 {
+			return fmt.Errorf("unmarshalJSON: Matrix4 nested array %d expects 4 elements, found %d", i, len(line))
+		}
+		for j, v := range line {
+			if rows {
+				out[j*4+i] = float32(v)
+			} else {
+				out[i*4+j] = float32(v)
+			}
+		}
+	}
+	*d = out
+	return nil
+}
+
+// appendJSONFloats appends the values as JSON array, using the shortest representation for float32.
+func appendJSONFloats(buf []byte, values []float32) ([]byte, error) {
+	buf = append(buf, '[')
+	for i, v := range values {
+		f := float64(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, errors.New("marshalJSON: unsupported value: " + strconv.FormatFloat(f, 'g', -1, 32))
+		}
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = strconv.AppendFloat(buf, f, 'g', -1, 32)
+	}
+	return append(buf, ']'), nil
+}
+
+func marshalBinaryFloats(values ...float64) []byte {
+	buf := make([]byte, 8*len(values))
+	for i, v := range values {
+		binary.LittleEndian.PutUint64(buf[i*8:], math.Float64bits(v))
+	}
+	return buf
+}
+
+func unmarshalBinaryFloats(data []byte, values ...*float64) error {
+	if len(data) != 8*len(values) {
+		return fmt.Errorf("unmarshalBinary: expects %d bytes, found %d", 8*len(values), len(data))
+	}
+	for i, v := range values {
+		*v = math.Float64frombits(binary.LittleEndian.Uint64(data[i*8:]))
+	}
+	return nil
+}
+
+func marshalTextFloats(values ...float64) []byte {
+	buf := []byte{'['}
+	for i, v := range values {
+		if i > 0 {
+			buf = append(buf, ' ')
+		}
+		buf = strconv.AppendFloat(buf, v, 'g', -1, 64)
+	}
+	return append(buf, ']')
+}
+
+func unmarshalTextFloats(text []byte, values ...*float64) error {
+	s := strings.TrimSpace(string(text))
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return errors.New("unmarshalText: missing brackets")
+	}
+	fields := strings.Fields(s[1 : len(s)-1])
+	if len(fields) != len(values) {
+		return fmt.Errorf("unmarshalText: expects %d components, found %d", len(values), len(fields))
+	}
+	parsed := make([]float64, len(fields)) // values are left untouched on error
+	for i, field := range fields {
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return fmt.Errorf("unmarshalText: %v", err)
+		}
+		parsed[i] = v
+	}
+	for i, v := range values {
+		*v = parsed[i]
+	}
+	return nil
+}
+
+// Types without methods, so that JSON uses the exported fields instead of MarshalText.
+type (
+	vector2Fields    Vector2
+	vector3Fields    Vector3
+	vector4Fields    Vector4
+	quaternionFields Quaternion
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (v Vector2) MarshalBinary() ([]byte, error) {
+	return marshalBinaryFloats(v.X, v.Y), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (v *Vector2) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryFloats(data, &v.X, &v.Y)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Vector2) MarshalText() ([]byte, error) {
+	return marshalTextFloats(v.X, v.Y), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *Vector2) UnmarshalText(text []byte) error {
+	return unmarshalTextFloats(text, &v.X, &v.Y)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v Vector2) MarshalJSON() ([]byte, error) {
+	return json.Marshal(vector2Fields(v))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *Vector2) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*vector2Fields)(v))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (v Vector3) MarshalBinary() ([]byte, error) {
+	return marshalBinaryFloats(v.X, v.Y, v.Z), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (v *Vector3) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryFloats(data, &v.X, &v.Y, &v.Z)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Vector3) MarshalText() ([]byte, error) {
+	return marshalTextFloats(v.X, v.Y, v.Z), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *Vector3) UnmarshalText(text []byte) error {
+	return unmarshalTextFloats(text, &v.X, &v.Y, &v.Z)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v Vector3) MarshalJSON() ([]byte, error) {
+	return json.Marshal(vector3Fields(v))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *Vector3) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*vector3Fields)(v))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (v Vector4) MarshalBinary() ([]byte, error) {
+	return marshalBinaryFloats(v.X, v.Y, v.Z, v.W), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (v *Vector4) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryFloats(data, &v.X, &v.Y, &v.Z, &v.W)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Vector4) MarshalText() ([]byte, error) {
+	return marshalTextFloats(v.X, v.Y, v.Z, v.W), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *Vector4) UnmarshalText(text []byte) error {
+	return unmarshalTextFloats(text, &v.X, &v.Y, &v.Z, &v.W)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v Vector4) MarshalJSON() ([]byte, error) {
+	return json.Marshal(vector4Fields(v))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *Vector4) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*vector4Fields)(v))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (q Quaternion) MarshalBinary() ([]byte, error) {
+	return marshalBinaryFloats(q.X, q.Y, q.Z, q.W), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (q *Quaternion) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryFloats(data, &q.X, &q.Y, &q.Z, &q.W)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (q Quaternion) MarshalText() ([]byte, error) {
+	return marshalTextFloats(q.X, q.Y, q.Z, q.W), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (q *Quaternion) UnmarshalText(text []byte) error {
+	return unmarshalTextFloats(text, &q.X, &q.Y, &q.Z, &q.W)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (q Quaternion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(quaternionFields(q))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (q *Quaternion) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*quaternionFields)(q))
+}
+
+// Camera controllers are encoded in JSON with their settings and goal state.
+// Decoding snaps the current state to the goal.
+
+type orbitCameraJSON struct {
+	Target      Vector3
+	Distance    float64
+	Yaw         float64
+	Pitch       float64
+	MinDistance float64
+	MaxDistance float64
+	MinPitch    float64
+	MaxPitch    float64
+	PanCenter   Vector3
+	PanRadius   float64
+	Smoothing   float64
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c *OrbitCamera) MarshalJSON() ([]byte, error) {
+	return json.Marshal(orbitCameraJSON{
+		Target:      c.goal.target,
+		Distance:    c.goal.distance,
+		Yaw:         c.goal.yaw,
+		Pitch:       c.goal.pitch,
+		MinDistance: c.MinDistance,
+		MaxDistance: c.MaxDistance,
+		MinPitch:    c.MinPitch,
+		MaxPitch:    c.MaxPitch,
+		PanCenter:   c.PanCenter,
+		PanRadius:   c.PanRadius,
+		Smoothing:   c.Smoothing,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Missing pitch limits default to DefaultPitchLimit.
+func (c *OrbitCamera) UnmarshalJSON(data []byte) error {
+	j := orbitCameraJSON{MinPitch: -DefaultPitchLimit, MaxPitch: DefaultPitchLimit}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	c.MinDistance = j.MinDistance
+	c.MaxDistance = j.MaxDistance
+	c.MinPitch = j.MinPitch
+	c.MaxPitch = j.MaxPitch
+	c.PanCenter = j.PanCenter
+	c.PanRadius = j.PanRadius
+	c.Smoothing = j.Smoothing
+	c.goal = orbitState{target: j.Target, distance: j.Distance, yaw: j.Yaw, pitch: j.Pitch}
+	c.constrain()
+	c.Snap()
+	return nil
+}
+
+type firstPersonCameraJSON struct {
+	Position  Vector3
+	Yaw       float64
+	Pitch     float64
+	MinPitch  float64
+	MaxPitch  float64
+	Smoothing float64
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c *FirstPersonCamera) MarshalJSON() ([]byte, error) {
+	return json.Marshal(firstPersonCameraJSON{
+		Position:  c.goal.position,
+		Yaw:       c.goal.yaw,
+		Pitch:     c.goal.pitch,
+		MinPitch:  c.MinPitch,
+		MaxPitch:  c.MaxPitch,
+		Smoothing: c.Smoothing,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Missing pitch limits default to DefaultPitchLimit.
+func (c *FirstPersonCamera) UnmarshalJSON(data []byte) error {
+	j := firstPersonCameraJSON{MinPitch: -DefaultPitchLimit, MaxPitch: DefaultPitchLimit}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	c.MinPitch = j.MinPitch
+	c.MaxPitch = j.MaxPitch
+	c.Smoothing = j.Smoothing
+	c.goal = firstPersonState{position: j.Position, yaw: j.Yaw, pitch: clamp(j.Pitch, c.MinPitch, c.MaxPitch)}
+	c.Snap()
+	return nil
+}
+
+type flyCameraJSON struct {
+	Position    Vector3
+	Orientation Quaternion
+	Smoothing   float64
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c *FlyCamera) MarshalJSON() ([]byte, error) {
+	return json.Marshal(flyCameraJSON{
+		Position:    c.goal.position,
+		Orientation: c.goal.orientation,
+		Smoothing:   c.Smoothing,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Missing orientation defaults to identity.
+func (c *FlyCamera) UnmarshalJSON(data []byte) error {
+	j := flyCameraJSON{Orientation: NewQuaternionIdentity()}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	c.Smoothing = j.Smoothing
+	c.goal = flyState{position: j.Position, orientation: j.Orientation.Normalize()}
+	c.Snap()
+	return nil
+}
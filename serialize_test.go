@@ -0,0 +1,227 @@
+package goglmath
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+)
+
+func TestMatrix4Binary(t *testing.T) {
+	m := testFormatMatrix()
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	// element 12 (translation x = 5.0) little-endian at offset 48
+	if want := []byte{0x00, 0x00, 0xa0, 0x40}; !bytes.Equal(data[48:52], want) {
+		t.Errorf("byte order: expected=%x got=%x", want, data[48:52])
+	}
+	var got Matrix4
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !Matrix4Equal(&got, &m) {
+		t.Errorf("round trip: expected=%v got=%v", m, got)
+	}
+	if err := got.UnmarshalBinary(data[:10]); err == nil {
+		t.Errorf("expected error for short data")
+	}
+}
+
+func TestMatrix4JSON(t *testing.T) {
+	m := testFormatMatrix()
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{m, `[1,0,0,0,0,1,0,0,0,0,1,0,5,-6.5,0.1,1]`},
+		{Matrix4RowMajor(m), `[[1,0,0,5],[0,1,0,-6.5],[0,0,1,0.1],[0,0,0,1]]`},
+		{Matrix4ColumnMajor(m), `[[1,0,0,0],[0,1,0,0],[0,0,1,0],[5,-6.5,0.1,1]]`},
+	}
+	for _, test := range tests {
+		data, err := json.Marshal(test.value)
+		if err != nil {
+			t.Fatalf("marshal %T: %v", test.value, err)
+		}
+		if string(data) != test.want {
+			t.Errorf("marshal %T: expected=%s got=%s", test.value, test.want, data)
+		}
+	}
+
+	var flat Matrix4
+	var rows Matrix4RowMajor
+	var cols Matrix4ColumnMajor
+	for i, dst := range []interface{}{&flat, &rows, &cols} {
+		if err := json.Unmarshal([]byte(tests[i].want), dst); err != nil {
+			t.Fatalf("unmarshal %T: %v", dst, err)
+		}
+	}
+	for _, got := range []Matrix4{flat, Matrix4(rows), Matrix4(cols)} {
+		if !Matrix4Equal(&got, &m) {
+			t.Errorf("round trip: expected=%v got=%v", m, got)
+		}
+	}
+
+	// struct field
+	type object struct {
+		Model Matrix4
+	}
+	data, err := json.Marshal(object{m})
+	if err != nil {
+		t.Fatalf("marshal struct: %v", err)
+	}
+	var o object
+	if err := json.Unmarshal(data, &o); err != nil || !Matrix4Equal(&o.Model, &m) {
+		t.Errorf("struct round trip: expected=%v got=%v err=%v", m, o.Model, err)
+	}
+
+	if err := json.Unmarshal([]byte(`[1,2,3]`), &flat); err == nil {
+		t.Errorf("expected error for short array")
+	}
+
+	// null is a no-op, as for other types in encoding/json
+	type nullable struct {
+		Model Matrix4
+		View  *Matrix4
+		Rows  Matrix4RowMajor
+		Cols  Matrix4ColumnMajor
+		Other int
+	}
+	n := nullable{Model: m, Rows: Matrix4RowMajor(m), Cols: Matrix4ColumnMajor(m)}
+	if err := json.Unmarshal([]byte(`{"Model":null,"View":null,"Rows":null,"Cols":null,"Other":1}`), &n); err != nil {
+		t.Fatalf("unmarshal null: %v", err)
+	}
+	if n.Model != m || n.View != nil || Matrix4(n.Rows) != m || Matrix4(n.Cols) != m || n.Other != 1 {
+		t.Errorf("unmarshal null: expected unchanged fields, got=%v", n)
+	}
+
+	before := rows
+	if err := json.Unmarshal([]byte(`[[9,9,9,9],[9,9,9]]`), &rows); err == nil {
+		t.Errorf("expected error for short nested array")
+	}
+	if rows != before {
+		t.Errorf("destination changed on error: %v", Matrix4(rows))
+	}
+}
+
+func TestGob(t *testing.T) {
+	type object struct {
+		Model    Matrix4
+		Position Vector3
+		Rotation Quaternion
+	}
+	in := object{testFormatMatrix(), Vector3{1, 2, 3}, NewQuaternionAxisAngle(Vector3{0, 1, 0}, 1)}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	var out object
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !Matrix4Equal(&out.Model, &in.Model) || out.Position != in.Position || out.Rotation != in.Rotation {
+		t.Errorf("round trip: expected=%v got=%v", in, out)
+	}
+}
+
+func TestMatrix4Text(t *testing.T) {
+	m := testFormatMatrix()
+	text, _ := m.MarshalText()
+	var got Matrix4
+	if err := got.UnmarshalText(text); err != nil || !Matrix4Equal(&got, &m) {
+		t.Errorf("round trip: expected=%v got=%v err=%v", m, got, err)
+	}
+}
+
+func TestVectorText(t *testing.T) {
+	v2 := Vector2{1, -2.5}
+	v3 := Vector3{1, -2.5, 0.1}
+	v4 := Vector4{1, -2.5, 0.1, 1e-20}
+	q := Quaternion{0, 0.6, 0, 0.8}
+	var g2 Vector2
+	var g3 Vector3
+	var g4 Vector4
+	var gq Quaternion
+	tests := []struct {
+		in   encoding.TextMarshaler
+		out  encoding.TextUnmarshaler
+		want string
+		json string
+	}{
+		{v2, &g2, "[1 -2.5]", `{"X":1,"Y":-2.5}`},
+		{v3, &g3, "[1 -2.5 0.1]", `{"X":1,"Y":-2.5,"Z":0.1}`},
+		{v4, &g4, "[1 -2.5 0.1 1e-20]", `{"X":1,"Y":-2.5,"Z":0.1,"W":1e-20}`},
+		{q, &gq, "[0 0.6 0 0.8]", `{"X":0,"Y":0.6,"Z":0,"W":0.8}`},
+	}
+	for _, test := range tests {
+		text, err := test.in.MarshalText()
+		if err != nil || string(text) != test.want {
+			t.Errorf("%T: marshal: expected=%q got=%q err=%v", test.in, test.want, text, err)
+		}
+		if err := test.out.UnmarshalText(text); err != nil {
+			t.Errorf("%T: unmarshal %q: %v", test.in, text, err)
+		}
+		if data, err := json.Marshal(test.in); err != nil || string(data) != test.json {
+			t.Errorf("%T: json: expected=%s got=%s err=%v", test.in, test.json, data, err)
+		}
+	}
+	if g2 != v2 || g3 != v3 || g4 != v4 || gq != q {
+		t.Errorf("round trip: expected=%v %v %v %v got=%v %v %v %v", v2, v3, v4, q, g2, g3, g4, gq)
+	}
+
+	for _, text := range []string{"", "[]", "1 2 3", "[1 2]", "[1 2 3 4]", "[1 2 x]", "[1, 2, 3]", "[1 2 3"} {
+		if err := g3.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("unmarshal %q: expected error", text)
+		}
+	}
+	if g3 != v3 {
+		t.Errorf("destination changed on error: %v", g3)
+	}
+
+	// text is also used for map keys
+	data, err := json.Marshal(map[Vector2]int{v2: 1})
+	if err != nil || string(data) != `{"[1 -2.5]":1}` {
+		t.Errorf("map key: got=%s err=%v", data, err)
+	}
+}
+
+func TestCameraJSON(t *testing.T) {
+	orbit := NewOrbitCamera(Vector3{1, 2, 3}, 10, 0.5, 0.25)
+	orbit.MaxDistance = 50
+	fps := NewFirstPersonCamera(Vector3{4, 5, 6}, 1, -0.5)
+	fps.Smoothing = 0.2
+	fly := NewFlyCamera(Vector3{7, 8, 9}, NewQuaternionAxisAngle(Vector3{1, 0, 0}, 0.3))
+
+	var orbit2 OrbitCamera
+	var fps2 FirstPersonCamera
+	var fly2 FlyCamera
+	pairs := []struct {
+		in, out interface {
+			ViewMatrix(*Matrix4)
+		}
+	}{
+		{orbit, &orbit2},
+		{fps, &fps2},
+		{fly, &fly2},
+	}
+	for _, p := range pairs {
+		data, err := json.Marshal(p.in)
+		if err != nil {
+			t.Fatalf("marshal %T: %v", p.in, err)
+		}
+		if err := json.Unmarshal(data, p.out); err != nil {
+			t.Fatalf("unmarshal %T: %v", p.out, err)
+		}
+		var want, got Matrix4
+		p.in.ViewMatrix(&want)
+		p.out.ViewMatrix(&got)
+		if !matrix4Close(&got, &want, 0.00001) {
+			t.Errorf("%T: view: expected=%v got=%v", p.in, want, got)
+		}
+	}
+	if orbit2.MaxDistance != 50 || fps2.Smoothing != 0.2 {
+		t.Errorf("settings not restored")
+	}
+}
@@ -0,0 +1,278 @@
+package goglmath
+
+import (
+	"errors"
+)
+
+// ErrTransformCycle is reported when reparenting a node would make it its own ancestor.
+var ErrTransformCycle = errors.New("transform node cycle")
+
+// TransformNode is a node in a transform hierarchy.
+//
+// The local transform is kept as translation, rotation and scale (TRS).
+// local matrix = T*R*S
+// world matrix = parent world matrix * local matrix
+//
+// Local and world matrices are recomputed lazily.
+// Changing a node marks its world matrix and the world matrices of all descendants as dirty.
+// Propagation stops at descendants already dirty, hence repeated changes are cheap.
+type TransformNode struct {
+	translation Vector3
+	rotation    Quaternion
+	scale       Vector3
+
+	local Matrix4
+	world Matrix4
+
+	localDirty bool
+	worldDirty bool
+
+	parent   *TransformNode
+	children []*TransformNode
+}
+
+// NewTransformNode creates a detached node with identity transform.
+func NewTransformNode() *TransformNode {
+	return &TransformNode{
+		rotation: NewQuaternionIdentity(),
+		scale:    Vector3{1, 1, 1},
+		local:    mat4identity,
+		world:    mat4identity,
+	}
+}
+
+// Translation returns the local translation.
+func (n *TransformNode) Translation() Vector3 {
+	return n.translation
+}
+
+// Rotation returns the local rotation.
+func (n *TransformNode) Rotation() Quaternion {
+	return n.rotation
+}
+
+// Scale returns the local scale.
+func (n *TransformNode) Scale() Vector3 {
+	return n.scale
+}
+
+// SetTranslation sets the local translation.
+func (n *TransformNode) SetTranslation(t Vector3) {
+	n.translation = t
+	n.localChanged()
+}
+
+// SetRotation sets the local rotation.
+// q should be a unit quaternion.
+func (n *TransformNode) SetRotation(q Quaternion) {
+	n.rotation = q
+	n.localChanged()
+}
+
+// SetScale sets the local scale.
+func (n *TransformNode) SetScale(s Vector3) {
+	n.scale = s
+	n.localChanged()
+}
+
+// SetLocal sets the local translation, rotation and scale at once.
+func (n *TransformNode) SetLocal(t Vector3, q Quaternion, s Vector3) {
+	n.translation = t
+	n.rotation = q
+	n.scale = s
+	n.localChanged()
+}
+
+// SetLocalMatrix sets the local transform from an affine matrix.
+// Shear and perspective can't be represented by the node and are discarded.
+func (n *TransformNode) SetLocalMatrix(m *Matrix4) error {
+	d, err := Decompose(m)
+	if err != nil {
+		return err
+	}
+	n.SetLocal(d.Translation, d.Rotation, d.Scale)
+	return nil
+}
+
+func (n *TransformNode) localChanged() {
+	n.localDirty = true
+	n.invalidate()
+}
+
+// invalidate marks the world matrix of the node and of its descendants as dirty.
+// A dirty node always has dirty descendants, so propagation stops at dirty nodes.
+func (n *TransformNode) invalidate() {
+	if n.worldDirty {
+		return
+	}
+	n.worldDirty = true
+	for _, c := range n.children {
+		c.invalidate()
+	}
+}
+
+// LocalMatrix returns the local matrix T*R*S.
+// The returned matrix must not be modified.
+func (n *TransformNode) LocalMatrix() *Matrix4 {
+	if n.localDirty {
+		l := &n.local
+		SetQuaternionMatrix(l, n.rotation)
+		sx := float32(n.scale.X)
+		sy := float32(n.scale.Y)
+		sz := float32(n.scale.Z)
+		l.data[0] *= sx
+		l.data[1] *= sx
+		l.data[2] *= sx
+		l.data[4] *= sy
+		l.data[5] *= sy
+		l.data[6] *= sy
+		l.data[8] *= sz
+		l.data[9] *= sz
+		l.data[10] *= sz
+		l.data[12] = float32(n.translation.X)
+		l.data[13] = float32(n.translation.Y)
+		l.data[14] = float32(n.translation.Z)
+		n.localDirty = false
+	}
+	return &n.local
+}
+
+// WorldMatrix returns the world matrix, recomputing dirty ancestors as needed.
+// The returned matrix must not be modified.
+func (n *TransformNode) WorldMatrix() *Matrix4 {
+	if n.worldDirty {
+		if n.parent == nil {
+			n.world = *n.LocalMatrix()
+		} else {
+			n.world = *n.parent.WorldMatrix()
+			n.world.Multiply(n.LocalMatrix())
+		}
+		n.worldDirty = false
+	}
+	return &n.world
+}
+
+// WorldPosition returns the origin of the node in world space.
+func (n *TransformNode) WorldPosition() Vector3 {
+	w := n.WorldMatrix()
+	return Vector3{float64(w.data[12]), float64(w.data[13]), float64(w.data[14])}
+}
+
+// Parent returns the parent node, or nil for root nodes.
+func (n *TransformNode) Parent() *TransformNode {
+	return n.parent
+}
+
+// Children returns the child nodes.
+// The returned slice must not be modified.
+func (n *TransformNode) Children() []*TransformNode {
+	return n.children
+}
+
+// Root returns the topmost ancestor of the node.
+func (n *TransformNode) Root() *TransformNode {
+	for n.parent != nil {
+		n = n.parent
+	}
+	return n
+}
+
+// IsAncestorOf reports if n is an ancestor of node.
+func (n *TransformNode) IsAncestorOf(node *TransformNode) bool {
+	for p := node.parent; p != nil; p = p.parent {
+		if p == n {
+			return true
+		}
+	}
+	return false
+}
+
+// SetParent attaches the node to a new parent, keeping the local transform.
+// The world pose follows the new parent.
+// Use nil parent to detach the node.
+func (n *TransformNode) SetParent(parent *TransformNode) error {
+	if parent == n || (parent != nil && n.IsAncestorOf(parent)) {
+		return ErrTransformCycle
+	}
+	n.attach(parent)
+	return nil
+}
+
+// SetParentKeepWorld attaches the node to a new parent, keeping the world pose.
+// The local transform is recomputed relative to the new parent.
+// Parents with non-uniform scale may introduce shear, which is discarded.
+func (n *TransformNode) SetParentKeepWorld(parent *TransformNode) error {
+	if parent == n || (parent != nil && n.IsAncestorOf(parent)) {
+		return ErrTransformCycle
+	}
+
+	local := *n.WorldMatrix()
+	if parent != nil {
+		var inv Matrix4
+		if err := inv.CopyInverseFrom(parent.WorldMatrix()); err != nil {
+			return err
+		}
+		inv.Multiply(&local)
+		local = inv
+	}
+	d, err := Decompose(&local)
+	if err != nil {
+		return err
+	}
+
+	n.attach(parent)
+	n.SetLocal(d.Translation, d.Rotation, d.Scale)
+	return nil
+}
+
+func (n *TransformNode) attach(parent *TransformNode) {
+	if n.parent == parent {
+		return
+	}
+	if old := n.parent; old != nil {
+		for i, c := range old.children {
+			if c == n {
+				copy(old.children[i:], old.children[i+1:])
+				old.children[len(old.children)-1] = nil
+				old.children = old.children[:len(old.children)-1]
+				break
+			}
+		}
+	}
+	n.parent = parent
+	if parent != nil {
+		parent.children = append(parent.children, n)
+	}
+	n.invalidate()
+}
+
+// Walk visits the node and its descendants in depth-first pre-order.
+// If visit returns false, the children of the visited node are skipped.
+func (n *TransformNode) Walk(visit func(node *TransformNode) bool) {
+	if !visit(n) {
+		return
+	}
+	for _, c := range n.children {
+		c.Walk(visit)
+	}
+}
+
+// UpdateWorld recomputes the dirty world matrices of the node and its descendants.
+// Calling UpdateWorld on the roots once per frame is cheaper than
+// querying WorldMatrix node by node, since each world matrix is computed once
+// from the already updated parent.
+func (n *TransformNode) UpdateWorld() {
+	n.WorldMatrix()
+	n.updateChildren()
+}
+
+func (n *TransformNode) updateChildren() {
+	for _, c := range n.children {
+		if c.worldDirty {
+			c.world = n.world
+			c.world.Multiply(c.LocalMatrix())
+			c.worldDirty = false
+		}
+		c.updateChildren()
+	}
+}
@@ -0,0 +1,107 @@
+package goglmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTransformNodeWorld(t *testing.T) {
+	root := NewTransformNode()
+	child := NewTransformNode()
+	if err := child.SetParent(root); err != nil {
+		t.Fatalf("set parent: %v", err)
+	}
+	root.SetTranslation(Vector3{10, 0, 0})
+	root.SetRotation(NewQuaternionAxisAngle(Vector3{0, 1, 0}, math.Pi/2))
+	child.SetTranslation(Vector3{0, 0, -1})
+
+	// child at -Z rotated 90 degrees around +Y lands at -X
+	if got, want := child.WorldPosition(), (Vector3{9, 0, 0}); !vector3Close(got, want) {
+		t.Errorf("world position: expected=%v got=%v", want, got)
+	}
+
+	root.SetScale(Vector3{2, 2, 2})
+	if got, want := child.WorldPosition(), (Vector3{8, 0, 0}); !vector3Close(got, want) {
+		t.Errorf("world position after parent scale: expected=%v got=%v", want, got)
+	}
+}
+
+func TestTransformNodeKeepWorld(t *testing.T) {
+	a := NewTransformNode()
+	a.SetLocal(Vector3{1, 2, 3}, NewQuaternionAxisAngle(Vector3{0, 0, 1}, 0.5), Vector3{2, 2, 2})
+	b := NewTransformNode()
+	b.SetLocal(Vector3{-4, 0, 1}, NewQuaternionAxisAngle(Vector3{1, 0, 0}, 1.2), Vector3{1, 1, 1})
+	node := NewTransformNode()
+	node.SetLocal(Vector3{0, 1, 0}, NewQuaternionAxisAngle(Vector3{0, 1, 0}, 0.3), Vector3{1, 2, 3})
+	node.SetParent(a)
+
+	before := *node.WorldMatrix()
+	if err := node.SetParentKeepWorld(b); err != nil {
+		t.Fatalf("reparent: %v", err)
+	}
+	if node.Parent() != b || len(a.Children()) != 0 || len(b.Children()) != 1 {
+		t.Errorf("bad hierarchy after reparent")
+	}
+	if !matrix4Close(node.WorldMatrix(), &before, 0.0001) {
+		t.Errorf("world changed: expected=%v got=%v", before, *node.WorldMatrix())
+	}
+}
+
+func TestTransformNodeCycle(t *testing.T) {
+	a := NewTransformNode()
+	b := NewTransformNode()
+	c := NewTransformNode()
+	b.SetParent(a)
+	c.SetParent(b)
+	if err := a.SetParent(c); err != ErrTransformCycle {
+		t.Errorf("expected=%v got=%v", ErrTransformCycle, err)
+	}
+	if err := a.SetParent(a); err != ErrTransformCycle {
+		t.Errorf("self parent: expected=%v got=%v", ErrTransformCycle, err)
+	}
+}
+
+func TestTransformNodeUpdateWorld(t *testing.T) {
+	root := NewTransformNode()
+	parent := root
+	var nodes []*TransformNode
+	for i := 0; i < 5; i++ {
+		n := NewTransformNode()
+		n.SetTranslation(Vector3{1, 0, 0})
+		n.SetParent(parent)
+		nodes = append(nodes, n)
+		parent = n
+	}
+	root.UpdateWorld()
+	for i, n := range nodes {
+		if n.worldDirty {
+			t.Errorf("node %d: still dirty after update", i)
+		}
+		if got, want := n.WorldPosition(), (Vector3{float64(i + 1), 0, 0}); !vector3Close(got, want) {
+			t.Errorf("node %d: expected=%v got=%v", i, want, got)
+		}
+	}
+
+	var visited int
+	root.Walk(func(n *TransformNode) bool {
+		visited++
+		return n != nodes[2]
+	})
+	if visited != 4 {
+		t.Errorf("walk: expected=4 visited got=%d", visited)
+	}
+}
+
+func BenchmarkTransformNodeUpdateWorld(b *testing.B) {
+	root := NewTransformNode()
+	for i := 0; i < 10000; i++ {
+		n := NewTransformNode()
+		n.SetTranslation(Vector3{float64(i), 0, 0})
+		n.SetParent(root)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		root.SetRotation(NewQuaternionAxisAngle(Vector3{0, 1, 0}, float64(n)))
+		root.UpdateWorld()
+	}
+}
@@ -0,0 +1,359 @@
+package goglmath
+
+import (
+	"math"
+)
+
+// Vector2 is a 2D vector.
+type Vector2 struct {
+	X, Y float64
+}
+
+// Vector3 is a 3D vector.
+type Vector3 struct {
+	X, Y, Z float64
+}
+
+// Vector4 is a 4D vector.
+// Vector4 is usually a homogeneous point (W=1) or direction (W=0).
+type Vector4 struct {
+	X, Y, Z, W float64
+}
+
+// Add returns v+u.
+func (v Vector2) Add(u Vector2) Vector2 {
+	return Vector2{v.X + u.X, v.Y + u.Y}
+}
+
+// Sub returns v-u.
+func (v Vector2) Sub(u Vector2) Vector2 {
+	return Vector2{v.X - u.X, v.Y - u.Y}
+}
+
+// Scale returns v multiplied by scalar s.
+func (v Vector2) Scale(s float64) Vector2 {
+	return Vector2{v.X * s, v.Y * s}
+}
+
+// Dot calculates the dot (scalar) product of two vectors.
+func (v Vector2) Dot(u Vector2) float64 {
+	return v.X*u.X + v.Y*u.Y
+}
+
+// Cross calculates the Z component of the cross product of two vectors lying on the XY plane.
+func (v Vector2) Cross(u Vector2) float64 {
+	return v.X*u.Y - v.Y*u.X
+}
+
+// LengthSquared calculates the squared length of a vector.
+func (v Vector2) LengthSquared() float64 {
+	return v.Dot(v)
+}
+
+// Length calculates the length of a vector.
+func (v Vector2) Length() float64 {
+	return math.Sqrt(v.LengthSquared())
+}
+
+// Distance calculates the distance between two points.
+func (v Vector2) Distance(u Vector2) float64 {
+	return u.Sub(v).Length()
+}
+
+// Normalize calculates a normalized copy of a vector.
+// The zero vector is returned unchanged.
+func (v Vector2) Normalize() Vector2 {
+	length := v.Length()
+	if length == 0 {
+		return v
+	}
+	return Vector2{v.X / length, v.Y / length}
+}
+
+// Lerp linearly interpolates from v (t=0) to u (t=1).
+func (v Vector2) Lerp(u Vector2, t float64) Vector2 {
+	return Vector2{v.X + (u.X-v.X)*t, v.Y + (u.Y-v.Y)*t}
+}
+
+// Reflect reflects the incident vector v off a surface with unit normal n.
+func (v Vector2) Reflect(n Vector2) Vector2 {
+	return v.Sub(n.Scale(2 * n.Dot(v)))
+}
+
+// Refract calculates the refraction of the unit incident vector v through a surface with unit normal n.
+// eta is the ratio of indices of refraction.
+// As in GLSL refract(), the zero vector is returned on total internal reflection.
+func (v Vector2) Refract(n Vector2, eta float64) Vector2 {
+	d := n.Dot(v)
+	k := 1 - eta*eta*(1-d*d)
+	if k < 0 {
+		return Vector2{}
+	}
+	return v.Scale(eta).Sub(n.Scale(eta*d + math.Sqrt(k)))
+}
+
+// Project calculates the projection of v onto u.
+// Projection onto the zero vector is the zero vector.
+func (v Vector2) Project(u Vector2) Vector2 {
+	uu := u.Dot(u)
+	if uu == 0 {
+		return Vector2{}
+	}
+	return u.Scale(v.Dot(u) / uu)
+}
+
+// Reject calculates the rejection of v from u, that is, the component of v orthogonal to u.
+func (v Vector2) Reject(u Vector2) Vector2 {
+	return v.Sub(v.Project(u))
+}
+
+// Angle calculates the angle in radians between two vectors.
+// The angle with the zero vector is 0.
+func (v Vector2) Angle(u Vector2) float64 {
+	return angle(v.Dot(u), v.Length()*u.Length())
+}
+
+// Add returns v+u.
+func (v Vector3) Add(u Vector3) Vector3 {
+	return Vector3{v.X + u.X, v.Y + u.Y, v.Z + u.Z}
+}
+
+// Sub returns v-u.
+func (v Vector3) Sub(u Vector3) Vector3 {
+	return Vector3{v.X - u.X, v.Y - u.Y, v.Z - u.Z}
+}
+
+// Scale returns v multiplied by scalar s.
+func (v Vector3) Scale(s float64) Vector3 {
+	return Vector3{v.X * s, v.Y * s, v.Z * s}
+}
+
+// Negate returns -v.
+func (v Vector3) Negate() Vector3 {
+	return Vector3{-v.X, -v.Y, -v.Z}
+}
+
+// Dot calculates the dot (scalar) product of two vectors.
+func (v Vector3) Dot(u Vector3) float64 {
+	return v.X*u.X + v.Y*u.Y + v.Z*u.Z
+}
+
+// Cross calculates the cross (vector) product of two vectors.
+func (v Vector3) Cross(u Vector3) Vector3 {
+	return Vector3{v.Y*u.Z - v.Z*u.Y, v.Z*u.X - v.X*u.Z, v.X*u.Y - v.Y*u.X}
+}
+
+// LengthSquared calculates the squared length of a vector.
+func (v Vector3) LengthSquared() float64 {
+	return v.X*v.X + v.Y*v.Y + v.Z*v.Z // v.Dot(v)
+}
+
+// Length calculates the length of a vector.
+func (v Vector3) Length() float64 {
+	return math.Sqrt(v.LengthSquared())
+}
+
+// DistanceSquared calculates the squared of the distance between two points.
+func (v Vector3) DistanceSquared(u Vector3) float64 {
+	return u.Sub(v).LengthSquared()
+}
+
+// Distance calculates the distance between two points.
+func (v Vector3) Distance(u Vector3) float64 {
+	return u.Sub(v).Length()
+}
+
+// Ortho reports if two vectors are orthogonal.
+func (v Vector3) Ortho(u Vector3) bool {
+	return closeToZero(v.Dot(u))
+}
+
+// Normalize calculates a normalized copy of a vector.
+// The zero vector is returned unchanged.
+func (v Vector3) Normalize() Vector3 {
+	length := v.Length()
+	if length == 0 {
+		return v
+	}
+	return Vector3{v.X / length, v.Y / length, v.Z / length}
+}
+
+// Lerp linearly interpolates from v (t=0) to u (t=1).
+func (v Vector3) Lerp(u Vector3, t float64) Vector3 {
+	return Vector3{v.X + (u.X-v.X)*t, v.Y + (u.Y-v.Y)*t, v.Z + (u.Z-v.Z)*t}
+}
+
+// Reflect reflects the incident vector v off a surface with unit normal n.
+func (v Vector3) Reflect(n Vector3) Vector3 {
+	return v.Sub(n.Scale(2 * n.Dot(v)))
+}
+
+// Refract calculates the refraction of the unit incident vector v through a surface with unit normal n.
+// eta is the ratio of indices of refraction.
+// As in GLSL refract(), the zero vector is returned on total internal reflection.
+func (v Vector3) Refract(n Vector3, eta float64) Vector3 {
+	d := n.Dot(v)
+	k := 1 - eta*eta*(1-d*d)
+	if k < 0 {
+		return Vector3{}
+	}
+	return v.Scale(eta).Sub(n.Scale(eta*d + math.Sqrt(k)))
+}
+
+// Project calculates the projection of v onto u.
+// Projection onto the zero vector is the zero vector.
+func (v Vector3) Project(u Vector3) Vector3 {
+	uu := u.Dot(u)
+	if uu == 0 {
+		return Vector3{}
+	}
+	return u.Scale(v.Dot(u) / uu)
+}
+
+// Reject calculates the rejection of v from u, that is, the component of v orthogonal to u.
+func (v Vector3) Reject(u Vector3) Vector3 {
+	return v.Sub(v.Project(u))
+}
+
+// Angle calculates the angle in radians between two vectors.
+// The angle with the zero vector is 0.
+func (v Vector3) Angle(u Vector3) float64 {
+	return angle(v.Dot(u), v.Length()*u.Length())
+}
+
+// Vector4 converts v to a homogeneous vector with the given w.
+// Use w=1 for points, w=0 for directions.
+func (v Vector3) Vector4(w float64) Vector4 {
+	return Vector4{v.X, v.Y, v.Z, w}
+}
+
+// Add returns v+u.
+func (v Vector4) Add(u Vector4) Vector4 {
+	return Vector4{v.X + u.X, v.Y + u.Y, v.Z + u.Z, v.W + u.W}
+}
+
+// Sub returns v-u.
+func (v Vector4) Sub(u Vector4) Vector4 {
+	return Vector4{v.X - u.X, v.Y - u.Y, v.Z - u.Z, v.W - u.W}
+}
+
+// Scale returns v multiplied by scalar s.
+func (v Vector4) Scale(s float64) Vector4 {
+	return Vector4{v.X * s, v.Y * s, v.Z * s, v.W * s}
+}
+
+// Dot calculates the dot (scalar) product of two vectors.
+func (v Vector4) Dot(u Vector4) float64 {
+	return v.X*u.X + v.Y*u.Y + v.Z*u.Z + v.W*u.W
+}
+
+// LengthSquared calculates the squared length of a vector.
+func (v Vector4) LengthSquared() float64 {
+	return v.Dot(v)
+}
+
+// Length calculates the length of a vector.
+func (v Vector4) Length() float64 {
+	return math.Sqrt(v.LengthSquared())
+}
+
+// Normalize calculates a normalized copy of a vector.
+// The zero vector is returned unchanged.
+func (v Vector4) Normalize() Vector4 {
+	length := v.Length()
+	if length == 0 {
+		return v
+	}
+	return Vector4{v.X / length, v.Y / length, v.Z / length, v.W / length}
+}
+
+// Lerp linearly interpolates from v (t=0) to u (t=1).
+func (v Vector4) Lerp(u Vector4, t float64) Vector4 {
+	return Vector4{v.X + (u.X-v.X)*t, v.Y + (u.Y-v.Y)*t, v.Z + (u.Z-v.Z)*t, v.W + (u.W-v.W)*t}
+}
+
+// Reflect reflects the incident vector v off a surface with unit normal n.
+func (v Vector4) Reflect(n Vector4) Vector4 {
+	return v.Sub(n.Scale(2 * n.Dot(v)))
+}
+
+// Refract calculates the refraction of the unit incident vector v through a surface with unit normal n.
+// eta is the ratio of indices of refraction.
+// As in GLSL refract(), the zero vector is returned on total internal reflection.
+func (v Vector4) Refract(n Vector4, eta float64) Vector4 {
+	d := n.Dot(v)
+	k := 1 - eta*eta*(1-d*d)
+	if k < 0 {
+		return Vector4{}
+	}
+	return v.Scale(eta).Sub(n.Scale(eta*d + math.Sqrt(k)))
+}
+
+// Project calculates the projection of v onto u.
+// Projection onto the zero vector is the zero vector.
+func (v Vector4) Project(u Vector4) Vector4 {
+	uu := u.Dot(u)
+	if uu == 0 {
+		return Vector4{}
+	}
+	return u.Scale(v.Dot(u) / uu)
+}
+
+// Reject calculates the rejection of v from u, that is, the component of v orthogonal to u.
+func (v Vector4) Reject(u Vector4) Vector4 {
+	return v.Sub(v.Project(u))
+}
+
+// Angle calculates the angle in radians between two vectors.
+// The angle with the zero vector is 0.
+// All four components are used: for homogeneous directions use W=0.
+func (v Vector4) Angle(u Vector4) float64 {
+	return angle(v.Dot(u), v.Length()*u.Length())
+}
+
+// Vector3 drops the W component.
+func (v Vector4) Vector3() Vector3 {
+	return Vector3{v.X, v.Y, v.Z}
+}
+
+// PerspectiveDivide divides X, Y and Z by W.
+// W=0 (a direction) is returned without division.
+func (v Vector4) PerspectiveDivide() Vector3 {
+	if v.W == 0 {
+		return v.Vector3()
+	}
+	invW := 1.0 / v.W
+	return Vector3{v.X * invW, v.Y * invW, v.Z * invW}
+}
+
+func angle(dot, lengths float64) float64 {
+	if lengths == 0 {
+		return 0
+	}
+	c := dot / lengths
+	// clamp rounding errors out of acos domain
+	if c > 1 {
+		c = 1
+	} else if c < -1 {
+		c = -1
+	}
+	return math.Acos(c)
+}
+
+// TransformVector4 multiplies this matrix [m] by vector v.
+func (m *Matrix4) TransformVector4(v Vector4) Vector4 {
+	x, y, z, w := m.Transform(v.X, v.Y, v.Z, v.W)
+	return Vector4{x, y, z, w}
+}
+
+// TransformPoint multiplies this matrix [m] by point p (w=1).
+// The result is divided by w, hence projective matrices are supported.
+func (m *Matrix4) TransformPoint(p Vector3) Vector3 {
+	return m.TransformVector4(p.Vector4(1)).PerspectiveDivide()
+}
+
+// TransformDirection multiplies this matrix [m] by direction d (w=0).
+// Translation does not affect directions.
+func (m *Matrix4) TransformDirection(d Vector3) Vector3 {
+	return m.TransformVector4(d.Vector4(0)).Vector3()
+}
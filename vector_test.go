@@ -0,0 +1,135 @@
+package goglmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVector3Cross(t *testing.T) {
+	x := Vector3{1, 0, 0}
+	y := Vector3{0, 1, 0}
+	z := x.Cross(y)
+	want := Vector3{0, 0, 1}
+	if z != want {
+		t.Errorf("expected=%v got=%v", want, z)
+	}
+	cx, cy, cz := Cross3(1, 0, 0, 0, 1, 0)
+	if cx != z.X || cy != z.Y || cz != z.Z {
+		t.Errorf("Cross3 mismatch: expected=%v got=%v,%v,%v", z, cx, cy, cz)
+	}
+}
+
+func TestVector3Normalize(t *testing.T) {
+	n := Vector3{1, 2, 3}.Normalize()
+	if size := n.Length(); size != 1.0 {
+		t.Errorf("expected=%v got=%v", 1.0, size)
+	}
+	zero := Vector3{}
+	if n := zero.Normalize(); n != zero {
+		t.Errorf("zero vector: expected=%v got=%v", zero, n)
+	}
+}
+
+func TestVector3ProjectReject(t *testing.T) {
+	v := Vector3{3, 4, 5}
+	axis := Vector3{0, 2, 0}
+	p := v.Project(axis)
+	if want := (Vector3{0, 4, 0}); p != want {
+		t.Errorf("project: expected=%v got=%v", want, p)
+	}
+	r := v.Reject(axis)
+	if want := (Vector3{3, 0, 5}); r != want {
+		t.Errorf("reject: expected=%v got=%v", want, r)
+	}
+	if !p.Ortho(r) {
+		t.Errorf("projection %v not orthogonal to rejection %v", p, r)
+	}
+}
+
+func TestVector3ReflectRefract(t *testing.T) {
+	n := Vector3{0, 1, 0}
+	i := Vector3{1, -1, 0}.Normalize()
+	r := i.Reflect(n)
+	if want := (Vector3{i.X, -i.Y, 0}); r != want {
+		t.Errorf("reflect: expected=%v got=%v", want, r)
+	}
+	if s := i.Refract(n, 1); s != i {
+		t.Errorf("refract eta=1: expected=%v got=%v", i, s)
+	}
+	if s := i.Refract(n, 1.5); s != (Vector3{}) {
+		t.Errorf("refract: expected total internal reflection, got=%v", s)
+	}
+}
+
+func TestVector3Angle(t *testing.T) {
+	a := Vector3{1, 0, 0}.Angle(Vector3{0, 0, -3})
+	if want := math.Pi / 2; a != want {
+		t.Errorf("expected=%v got=%v", want, a)
+	}
+	if a := (Vector3{1, 1, 1}).Angle(Vector3{}); a != 0 {
+		t.Errorf("zero vector: expected=0 got=%v", a)
+	}
+}
+
+func TestVector2(t *testing.T) {
+	v := Vector2{1, 0}
+	u := Vector2{0, 2}
+	if c := v.Cross(u); c != 2 {
+		t.Errorf("cross: expected=2 got=%v", c)
+	}
+	if m := v.Lerp(u, .5); m != (Vector2{.5, 1}) {
+		t.Errorf("lerp: expected=%v got=%v", Vector2{.5, 1}, m)
+	}
+}
+
+func TestVector4(t *testing.T) {
+	v := Vector4{3, 4, 5, 6}
+	axis := Vector4{0, 0, 0, 2}
+	p := v.Project(axis)
+	if want := (Vector4{0, 0, 0, 6}); p != want {
+		t.Errorf("project: expected=%v got=%v", want, p)
+	}
+	r := v.Reject(axis)
+	if want := (Vector4{3, 4, 5, 0}); r != want {
+		t.Errorf("reject: expected=%v got=%v", want, r)
+	}
+	if a := p.Angle(r); a != math.Pi/2 {
+		t.Errorf("angle: expected=%v got=%v", math.Pi/2, a)
+	}
+	if a := v.Angle(Vector4{}); a != 0 {
+		t.Errorf("zero vector: expected=0 got=%v", a)
+	}
+
+	n := Vector4{0, 1, 0, 0}
+	i := Vector4{1, -1, 0, 0}.Normalize()
+	if s := i.Reflect(n); s != (Vector4{i.X, -i.Y, 0, 0}) {
+		t.Errorf("reflect: expected=%v got=%v", Vector4{i.X, -i.Y, 0, 0}, s)
+	}
+	if s := i.Refract(n, 1); s != i {
+		t.Errorf("refract eta=1: expected=%v got=%v", i, s)
+	}
+	if s := i.Refract(n, 1.5); s != (Vector4{}) {
+		t.Errorf("refract: expected total internal reflection, got=%v", s)
+	}
+}
+
+func TestMatrix4TransformPointDirection(t *testing.T) {
+	m := NewMatrix4Identity()
+	m.Translate(1, 2, 3, 1)
+	p := m.TransformPoint(Vector3{1, 1, 1})
+	if want := (Vector3{2, 3, 4}); p != want {
+		t.Errorf("point: expected=%v got=%v", want, p)
+	}
+	d := m.TransformDirection(Vector3{1, 1, 1})
+	if want := (Vector3{1, 1, 1}); d != want {
+		t.Errorf("direction: expected=%v got=%v", want, d)
+	}
+}
+
+func BenchmarkVector3Cross(b *testing.B) {
+	v := Vector3{1, 2, 3}
+	u := Vector3{4, 5, 6}
+	for n := 0; n < b.N; n++ {
+		v.Cross(u)
+	}
+}